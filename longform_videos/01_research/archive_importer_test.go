@@ -0,0 +1,160 @@
+package research
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashIDStableAndShort(t *testing.T) {
+	a := hashID("https://example.com/post/1")
+	b := hashID("https://example.com/post/1")
+	if a != b {
+		t.Fatalf("hashID not stable: %q != %q", a, b)
+	}
+	if len(a) != 12 {
+		t.Fatalf("hashID length = %d, want 12", len(a))
+	}
+	if c := hashID("https://example.com/post/2"); c == a {
+		t.Fatalf("hashID should differ for different input, both got %q", a)
+	}
+}
+
+func TestFirstSentence(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"A body without punctuation short enough to keep whole", "A body without punctuation short enough to keep whole"},
+		{"The victim vanished in 1987. Investigators reopened the case in 2019.", "The victim vanished in 1987"},
+		{"Detectives asked: who saw her last?\nA neighbor came forward.", "Detectives asked: who saw her last"},
+	}
+	for _, c := range cases {
+		if got := firstSentence(c.text); got != c.want {
+			t.Errorf("firstSentence(%q) = %q, want %q", c.text, got, c.want)
+		}
+	}
+}
+
+func TestFirstSentenceTruncatesLongUnpunctuatedText(t *testing.T) {
+	long := ""
+	for i := 0; i < 200; i++ {
+		long += "x"
+	}
+	got := firstSentence(long)
+	if len(got) != 120 {
+		t.Fatalf("firstSentence should truncate to 120 chars, got %d", len(got))
+	}
+}
+
+func TestStripHTML(t *testing.T) {
+	got := stripHTML("<p>She went missing &amp; was never found.</p>")
+	want := "She went missing & was never found."
+	if got != want {
+		t.Fatalf("stripHTML = %q, want %q", got, want)
+	}
+}
+
+func TestParseMarkdownArchive(t *testing.T) {
+	dir := t.TempDir()
+	post := "---\n" +
+		"title: The Vanishing of Jane Doe\n" +
+		"source_url: https://example.com/case-file\n" +
+		"published_at: 2024-02-01T00:00:00Z\n" +
+		"image_urls:\n" +
+		"  - https://example.com/case-file.jpg\n" +
+		"---\n" +
+		"Jane Doe disappeared from her home in 1998.\n"
+	if err := os.WriteFile(filepath.Join(dir, "jane-doe.md"), []byte(post), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "no-front-matter.md"), []byte("just a plain file"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	stories, err := parseMarkdownArchive(dir)
+	if err != nil {
+		t.Fatalf("parseMarkdownArchive: %v", err)
+	}
+	if len(stories) != 1 {
+		t.Fatalf("got %d stories, want 1 (the malformed file should be skipped)", len(stories))
+	}
+	s := stories[0]
+	if s.Title != "The Vanishing of Jane Doe" {
+		t.Errorf("Title = %q", s.Title)
+	}
+	if s.SourceURL != "https://example.com/case-file" {
+		t.Errorf("SourceURL = %q", s.SourceURL)
+	}
+	if len(s.ImageURLs) != 1 || s.ImageURLs[0] != "https://example.com/case-file.jpg" {
+		t.Errorf("ImageURLs = %v", s.ImageURLs)
+	}
+}
+
+func TestParseRedditExportCSVPosts(t *testing.T) {
+	dir := t.TempDir()
+	csvData := "id,title,url,date\n" +
+		"abc123,A Cold Case Reopened,https://reddit.com/r/x/abc123,2023-04-05 13:45:22 UTC\n"
+	path := filepath.Join(dir, "posts.csv")
+	if err := os.WriteFile(path, []byte(csvData), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	stories, err := parseRedditExportCSV(path)
+	if err != nil {
+		t.Fatalf("parseRedditExportCSV: %v", err)
+	}
+	if len(stories) != 1 {
+		t.Fatalf("got %d stories, want 1", len(stories))
+	}
+	s := stories[0]
+	if s.Title != "A Cold Case Reopened" {
+		t.Errorf("Title = %q", s.Title)
+	}
+	if s.PublishedAt != "2023-04-05T13:45:22Z" {
+		t.Errorf("PublishedAt = %q, want RFC3339", s.PublishedAt)
+	}
+	if s.ID != "reddit_export_abc123" {
+		t.Errorf("ID = %q", s.ID)
+	}
+}
+
+func TestParseRedditExportCSVRejectsUnrecognizedHeader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mystery.csv")
+	if err := os.WriteFile(path, []byte("foo,bar\n1,2\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := parseRedditExportCSV(path); err == nil {
+		t.Fatalf("expected an error for a CSV with neither a title nor body column")
+	}
+}
+
+func TestParseMastodonOutbox(t *testing.T) {
+	dir := t.TempDir()
+	outbox := `{
+		"orderedItems": [
+			{"object": {"id": "https://mastodon.social/@x/1", "content": "<p>She vanished in 1998. Police reopened the case.</p>", "published": "2024-01-01T00:00:00Z", "attachment": [{"url": "https://example.com/a.jpg", "mediaType": "image/jpeg"}]}},
+			{"object": {"id": "https://mastodon.social/@x/2", "content": "<p></p>", "published": "2024-01-02T00:00:00Z"}}
+		]
+	}`
+	path := filepath.Join(dir, "outbox.json")
+	if err := os.WriteFile(path, []byte(outbox), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	stories, err := parseMastodonOutbox(path)
+	if err != nil {
+		t.Fatalf("parseMastodonOutbox: %v", err)
+	}
+	if len(stories) != 1 {
+		t.Fatalf("got %d stories, want 1 (the empty-body note should be skipped)", len(stories))
+	}
+	s := stories[0]
+	if s.Title != "She vanished in 1998" {
+		t.Errorf("Title = %q", s.Title)
+	}
+	if len(s.ImageURLs) != 1 || s.ImageURLs[0] != "https://example.com/a.jpg" {
+		t.Errorf("ImageURLs = %v", s.ImageURLs)
+	}
+}
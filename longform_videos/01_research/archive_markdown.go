@@ -0,0 +1,101 @@
+package research
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"true-crime-pipeline/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// markdownFrontMatter is the YAML block expected at the top of each post,
+// e.g.:
+//
+//	---
+//	title: The Vanishing of Jane Doe
+//	source_url: https://example.com/case-file
+//	published_at: 2024-02-01T00:00:00Z
+//	image_urls:
+//	  - https://example.com/case-file.jpg
+//	---
+//	The body of the post, used as Story.Body...
+type markdownFrontMatter struct {
+	Title       string   `yaml:"title"`
+	Source      string   `yaml:"source"`
+	SourceURL   string   `yaml:"source_url"`
+	PublishedAt string   `yaml:"published_at"`
+	ImageURLs   []string `yaml:"image_urls"`
+}
+
+// parseMarkdownArchive reads every *.md file directly under dir as one
+// Story, skipping (with a log line) any that has no parseable front
+// matter rather than failing the whole import.
+func parseMarkdownArchive(dir string) ([]*types.Story, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var stories []*types.Story
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".md") {
+			continue
+		}
+		story, err := parseMarkdownPost(filepath.Join(dir, e.Name()))
+		if err != nil {
+			log.Printf("[research] import: skipping %s: %v", e.Name(), err)
+			continue
+		}
+		stories = append(stories, story)
+	}
+	return stories, nil
+}
+
+func parseMarkdownPost(path string) (*types.Story, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	const delim = "---"
+	text := strings.TrimPrefix(string(data), "\uFEFF") // tolerate a BOM
+	if !strings.HasPrefix(strings.TrimSpace(text), delim) {
+		return nil, fmt.Errorf("no YAML front matter")
+	}
+
+	rest := strings.TrimPrefix(strings.TrimSpace(text), delim)
+	parts := strings.SplitN(rest, delim, 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unterminated front matter")
+	}
+
+	var front markdownFrontMatter
+	if err := yaml.Unmarshal([]byte(parts[0]), &front); err != nil {
+		return nil, fmt.Errorf("parse front matter: %w", err)
+	}
+
+	body := strings.TrimSpace(parts[1])
+	title := front.Title
+	if title == "" {
+		title = firstSentence(body)
+	}
+	source := front.Source
+	if source == "" {
+		source = "Archive Import"
+	}
+
+	return &types.Story{
+		ID:          fmt.Sprintf("md_%s", hashID(filepath.Base(path))),
+		Title:       title,
+		Body:        body,
+		Source:      source,
+		SourceURL:   front.SourceURL,
+		PublishedAt: front.PublishedAt,
+		ImageURLs:   front.ImageURLs,
+		Keywords:    extractKeywords(title + " " + body),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package research
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strings"
+
+	"true-crime-pipeline/types"
+)
+
+// mastodonOutbox is the subset of an ActivityPub OrderedCollection this
+// importer cares about, as produced by Mastodon's "Request your data"
+// account export.
+type mastodonOutbox struct {
+	OrderedItems []mastodonActivity `json:"orderedItems"`
+}
+
+type mastodonActivity struct {
+	Object mastodonNote `json:"object"`
+}
+
+type mastodonNote struct {
+	ID         string `json:"id"`
+	Content    string `json:"content"`
+	Published  string `json:"published"`
+	Attachment []struct {
+		URL       string `json:"url"`
+		MediaType string `json:"mediaType"`
+	} `json:"attachment"`
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// parseMastodonOutbox normalizes every Note in a Mastodon outbox.json into
+// a Story, stripping the HTML wrapper Mastodon stores post content in.
+func parseMastodonOutbox(path string) ([]*types.Story, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var outbox mastodonOutbox
+	if err := json.Unmarshal(data, &outbox); err != nil {
+		return nil, fmt.Errorf("import: parse Mastodon outbox %s: %w", path, err)
+	}
+
+	var stories []*types.Story
+	for _, item := range outbox.OrderedItems {
+		note := item.Object
+		body := stripHTML(note.Content)
+		if body == "" {
+			continue
+		}
+
+		story := &types.Story{
+			ID:          fmt.Sprintf("mastodon_%s", hashID(note.ID)),
+			Title:       firstSentence(body),
+			Body:        body,
+			Source:      "Mastodon Archive",
+			SourceURL:   note.ID,
+			PublishedAt: note.Published,
+			Keywords:    extractKeywords(body),
+		}
+		for _, att := range note.Attachment {
+			if strings.HasPrefix(att.MediaType, "image/") {
+				story.ImageURLs = append(story.ImageURLs, att.URL)
+			}
+		}
+		stories = append(stories, story)
+	}
+	return stories, nil
+}
+
+// stripHTML removes tags and unescapes entities from Mastodon's stored
+// post HTML (typically just <p>...</p> with the occasional <a>/<br>).
+func stripHTML(s string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(s, " ")))
+}
@@ -0,0 +1,117 @@
+package research
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/types"
+)
+
+// redditExportDateLayout is the timestamp format Reddit's official GDPR
+// data export uses in posts.csv/comments.csv, e.g. "2023-04-05 13:45:22 UTC".
+const redditExportDateLayout = "2006-01-02 15:04:05 MST"
+
+// parseRedditExportCSV reads a posts.csv or comments.csv from Reddit's
+// official "request your data" export, telling the two apart by header
+// column rather than filename since users rename these freely.
+func parseRedditExportCSV(path string) ([]*types.Story, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("import: read CSV header of %s: %w", path, err)
+	}
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	_, isPosts := col["title"]
+	if !isPosts {
+		if _, isComments := col["body"]; !isComments {
+			return nil, fmt.Errorf("import: %s doesn't look like a Reddit posts.csv or comments.csv export", path)
+		}
+	}
+
+	var stories []*types.Story
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("import: read CSV row of %s: %w", path, err)
+		}
+
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(rec) {
+				return rec[i]
+			}
+			return ""
+		}
+
+		id := get("id")
+		if id == "" {
+			continue
+		}
+
+		var title, body, link string
+		if isPosts {
+			title, body, link = get("title"), get("title"), get("url")
+		} else {
+			body = get("body")
+			title = firstSentence(body)
+			link = get("permalink")
+		}
+		if title == "" {
+			continue
+		}
+
+		stories = append(stories, &types.Story{
+			ID:          fmt.Sprintf("reddit_export_%s", id),
+			Title:       title,
+			Body:        body,
+			Source:      "Reddit Data Export",
+			SourceURL:   normalizeRedditLink(link),
+			PublishedAt: normalizeRedditExportDate(get("date")),
+			Keywords:    extractKeywords(title + " " + body),
+			ImageURLs:   imageURLsFrom(link),
+		})
+	}
+	return stories, nil
+}
+
+func normalizeRedditLink(link string) string {
+	if link == "" || strings.HasPrefix(link, "http") {
+		return link
+	}
+	return "https://reddit.com" + link
+}
+
+// normalizeRedditExportDate honors the export's date precision into
+// RFC3339, the format scoreStory expects for its recency bonus; an
+// unparseable date is passed through as-is rather than dropped.
+func normalizeRedditExportDate(raw string) string {
+	if t, err := time.Parse(redditExportDateLayout, raw); err == nil {
+		return t.Format(time.RFC3339)
+	}
+	return raw
+}
+
+func imageURLsFrom(link string) []string {
+	if isImageURL(link) {
+		return []string{link}
+	}
+	return nil
+}
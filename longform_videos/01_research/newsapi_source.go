@@ -0,0 +1,113 @@
+package research
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/httpcache"
+	"true-crime-pipeline/types"
+
+	"github.com/google/uuid"
+)
+
+func init() {
+	RegisterSource(&newsAPISource{httpClient: &http.Client{Timeout: 15 * time.Second}})
+}
+
+// newsAPISource fetches articles from newsapi.org for Research.NewsKeywords,
+// limited to the first 3 keywords per run to save API quota.
+type newsAPISource struct {
+	httpClient *http.Client
+}
+
+func (s *newsAPISource) Name() string { return "newsapi" }
+
+func (s *newsAPISource) Enabled(cfg *config.Config) bool {
+	n := cfg.Research.NewsAPI
+	return n.Enabled && os.Getenv(n.APIKeyEnv) != ""
+}
+
+type newsAPIResponse struct {
+	Articles []struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Content     string `json:"content"`
+		URL         string `json:"url"`
+		URLToImage  string `json:"urlToImage"`
+		PublishedAt string `json:"publishedAt"`
+		Source      struct {
+			Name string `json:"name"`
+		} `json:"source"`
+	} `json:"articles"`
+}
+
+func (s *newsAPISource) Fetch(ctx context.Context, cfg *config.Config) ([]*types.Story, error) {
+	apiKey := os.Getenv(cfg.Research.NewsAPI.APIKeyEnv)
+
+	var stories []*types.Story
+	from := time.Now().AddDate(0, 0, -cfg.Research.StoryLookbackDays).Format("2006-01-02")
+
+	keywords := cfg.Research.NewsKeywords
+	if len(keywords) > 3 {
+		keywords = keywords[:3] // limit to 3 to save quota
+	}
+
+	for _, keyword := range keywords {
+		reqURL := fmt.Sprintf(
+			"https://newsapi.org/v2/everything?q=%s&from=%s&sortBy=popularity&language=en&pageSize=10&apiKey=%s",
+			url.QueryEscape(keyword), from, apiKey,
+		)
+
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			return stories, err
+		}
+		resp, err := httpcache.Do(ctx, s.httpClient, req)
+		if err != nil {
+			continue
+		}
+
+		var result newsAPIResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if decodeErr != nil {
+			continue
+		}
+
+		for _, article := range result.Articles {
+			if article.Title == "" || article.Title == "[Removed]" {
+				continue
+			}
+			body := article.Description
+			if article.Content != "" {
+				body = article.Content
+			}
+			story := &types.Story{
+				ID:          fmt.Sprintf("news_%s", uuid.NewString()[:8]),
+				Title:       article.Title,
+				Body:        body,
+				Source:      article.Source.Name,
+				SourceURL:   article.URL,
+				PublishedAt: article.PublishedAt,
+				Keywords:    extractKeywords(article.Title + " " + body),
+			}
+			if article.URLToImage != "" {
+				story.ImageURLs = append(story.ImageURLs, article.URLToImage)
+			}
+			stories = append(stories, story)
+		}
+
+		select {
+		case <-ctx.Done():
+			return stories, ctx.Err()
+		case <-time.After(200 * time.Millisecond): // be polite to the API
+		}
+	}
+	return stories, nil
+}
@@ -0,0 +1,164 @@
+package research
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/httpcache"
+	"true-crime-pipeline/types"
+)
+
+func init() {
+	RegisterSource(&redditSource{httpClient: &http.Client{Timeout: 15 * time.Second}})
+}
+
+// redditSource fetches hot posts from the subreddits configured under
+// Research.Subreddits, filtered by lookback window, score and comment
+// count.
+type redditSource struct {
+	httpClient *http.Client
+}
+
+func (s *redditSource) Name() string { return "reddit" }
+
+func (s *redditSource) Enabled(cfg *config.Config) bool {
+	r := cfg.Research.Reddit
+	if !r.Enabled || len(cfg.Research.Subreddits) == 0 {
+		return false
+	}
+	return os.Getenv(r.ClientIDEnv) != "" && os.Getenv(r.ClientSecretEnv) != ""
+}
+
+func (s *redditSource) Fetch(ctx context.Context, cfg *config.Config) ([]*types.Story, error) {
+	r := cfg.Research.Reddit
+	clientID := os.Getenv(r.ClientIDEnv)
+	clientSecret := os.Getenv(r.ClientSecretEnv)
+	userAgent := os.Getenv(r.UserAgentEnv)
+	if userAgent == "" {
+		userAgent = "true-crime-pipeline/1.0"
+	}
+
+	token, err := s.getToken(ctx, clientID, clientSecret, userAgent)
+	if err != nil {
+		return nil, fmt.Errorf("reddit auth failed: %w", err)
+	}
+
+	var stories []*types.Story
+	cutoff := time.Now().AddDate(0, 0, -cfg.Research.StoryLookbackDays)
+
+	for _, subreddit := range cfg.Research.Subreddits {
+		posts, err := s.fetchPosts(ctx, subreddit, token, userAgent)
+		if err != nil {
+			log.Printf("[research] reddit r/%s error: %v", subreddit, err)
+			continue
+		}
+
+		for _, post := range posts {
+			createdAt := time.Unix(int64(post.Data.Created), 0)
+			if createdAt.Before(cutoff) {
+				continue
+			}
+			if post.Data.Score < cfg.Research.MinRedditScore {
+				continue
+			}
+			if post.Data.NumComments < cfg.Research.MinComments {
+				continue
+			}
+
+			story := &types.Story{
+				ID:          fmt.Sprintf("reddit_%s", post.Data.ID),
+				Title:       post.Data.Title,
+				Body:        post.Data.Selftext,
+				Source:      fmt.Sprintf("r/%s", subreddit),
+				SourceURL:   fmt.Sprintf("https://reddit.com%s", post.Data.Permalink),
+				PublishedAt: createdAt.Format(time.RFC3339),
+				Keywords:    extractKeywords(post.Data.Title + " " + post.Data.Selftext),
+			}
+			if post.Data.URL != "" && isImageURL(post.Data.URL) {
+				story.ImageURLs = append(story.ImageURLs, post.Data.URL)
+			}
+			stories = append(stories, story)
+		}
+	}
+	return stories, nil
+}
+
+type redditTokenResp struct {
+	AccessToken string `json:"access_token"`
+}
+
+type redditPost struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Selftext    string  `json:"selftext"`
+	Permalink   string  `json:"permalink"`
+	URL         string  `json:"url"`
+	Score       int     `json:"score"`
+	NumComments int     `json:"num_comments"`
+	Created     float64 `json:"created_utc"`
+}
+
+type redditChild struct {
+	Data redditPost `json:"data"`
+}
+
+type redditListing struct {
+	Data struct {
+		Children []redditChild `json:"children"`
+	} `json:"data"`
+}
+
+func (s *redditSource) getToken(ctx context.Context, clientID, clientSecret, userAgent string) (string, error) {
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://www.reddit.com/api/v1/access_token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(clientID, clientSecret)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpcache.Do(ctx, s.httpClient, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var tok redditTokenResp
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", err
+	}
+	return tok.AccessToken, nil
+}
+
+func (s *redditSource) fetchPosts(ctx context.Context, subreddit, token, userAgent string) ([]redditChild, error) {
+	reqURL := fmt.Sprintf("https://oauth.reddit.com/r/%s/hot?limit=25", subreddit)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := httpcache.Do(ctx, s.httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var listing redditListing
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, err
+	}
+	return listing.Data.Children, nil
+}
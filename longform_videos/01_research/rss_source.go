@@ -0,0 +1,220 @@
+package research
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/httpcache"
+	"true-crime-pipeline/types"
+
+	"github.com/google/uuid"
+	"github.com/mmcdole/gofeed"
+)
+
+func init() {
+	parser := gofeed.NewParser()
+	parser.Client = &http.Client{Timeout: 15 * time.Second}
+	RegisterSource(&rssSource{parser: parser})
+}
+
+// defaultFeedIntervalMinutes is how often a registered feed is polled when
+// its directory has no interval_minutes file.
+const defaultFeedIntervalMinutes = 60
+
+// rssSource polls every feed registered under Research.RSS.FeedsDir,
+// parsing RSS2/Atom/JSON Feed alike via gofeed rather than splitting raw
+// XML on "<item>" — see the old parseRSSItems, which broke on Atom feeds,
+// namespaced tags and HTML-entity-encoded titles.
+type rssSource struct {
+	parser *gofeed.Parser
+}
+
+func (s *rssSource) Name() string { return "rss" }
+
+func (s *rssSource) Enabled(cfg *config.Config) bool {
+	return cfg.Research.RSS.Enabled && cfg.Research.RSS.FeedsDir != ""
+}
+
+// feedInfo is one registered feed, loaded from a subdirectory of
+// Research.RSS.FeedsDir named after the feed (e.g. "bbc_news"). Each
+// directory holds a "url" file with the feed's RSS/Atom/JSON Feed URL and
+// an "interval_minutes" file with its polling interval, the same layout
+// the raccoon aggregator uses for its feed registry.
+type feedInfo struct {
+	Name     string
+	URL      string
+	Interval time.Duration
+}
+
+func (s *rssSource) Fetch(ctx context.Context, cfg *config.Config) ([]*types.Story, error) {
+	if cache := httpcache.FromContext(ctx); cache != nil {
+		s.parser.Client.Transport = cache.Transport(s.parser.Client.Transport)
+	}
+
+	feeds, err := loadFeedInfos(cfg.Research.RSS.FeedsDir)
+	if err != nil {
+		return nil, fmt.Errorf("rss: load feed registry: %w", err)
+	}
+	if len(feeds) == 0 {
+		return nil, fmt.Errorf("rss: no feeds registered under %s", cfg.Research.RSS.FeedsDir)
+	}
+
+	state := loadFeedState(cfg.Paths.RSSFeedState)
+	now := time.Now()
+
+	var stories []*types.Story
+	for _, feed := range feeds {
+		if last, ok := state[feed.Name]; ok && now.Sub(last) < feed.Interval {
+			continue
+		}
+
+		parsed, err := s.parser.ParseURLWithContext(feed.URL, ctx)
+		if err != nil {
+			log.Printf("[research] rss %s: error: %v", feed.Name, err)
+			continue
+		}
+
+		for _, item := range parsed.Items {
+			stories = append(stories, storyFromFeedItem(feed.Name, item))
+		}
+		state[feed.Name] = now
+	}
+
+	saveFeedState(cfg.Paths.RSSFeedState, state)
+	return stories, nil
+}
+
+// storyFromFeedItem normalizes one gofeed.Item — already unified across
+// RSS2/Atom/JSON Feed — into a Story, preferring content:encoded over the
+// plain description and honoring PubDate/Updated precision into
+// PublishedAt.
+func storyFromFeedItem(feedName string, item *gofeed.Item) *types.Story {
+	body := item.Description
+	if item.Content != "" {
+		body = item.Content
+	}
+
+	published := item.Published
+	switch {
+	case item.PublishedParsed != nil:
+		published = item.PublishedParsed.Format(time.RFC3339)
+	case item.UpdatedParsed != nil:
+		published = item.UpdatedParsed.Format(time.RFC3339)
+	}
+
+	return &types.Story{
+		ID:          fmt.Sprintf("rss_%s", uuid.NewString()[:8]),
+		Title:       item.Title,
+		Body:        body,
+		Source:      feedName,
+		SourceURL:   item.Link,
+		PublishedAt: published,
+		Keywords:    extractKeywords(item.Title + " " + body),
+		ImageURLs:   feedItemImages(item),
+	}
+}
+
+// feedItemImages pulls every plausible image URL off item: the Atom/RSS2
+// <image>, media:thumbnail and media:content extensions, and any
+// image/* enclosure.
+func feedItemImages(item *gofeed.Item) []string {
+	var urls []string
+	if item.Image != nil && item.Image.URL != "" {
+		urls = append(urls, item.Image.URL)
+	}
+	if media, ok := item.Extensions["media"]; ok {
+		for _, tag := range []string{"thumbnail", "content"} {
+			for _, ext := range media[tag] {
+				if u := ext.Attrs["url"]; u != "" {
+					urls = append(urls, u)
+				}
+			}
+		}
+	}
+	for _, enc := range item.Enclosures {
+		if enc.URL != "" && strings.HasPrefix(enc.Type, "image/") {
+			urls = append(urls, enc.URL)
+		}
+	}
+	return urls
+}
+
+// --- Feed registry ---
+
+// loadFeedInfos walks dir, treating each subdirectory as one registered
+// feed. Subdirectories missing a "url" file are skipped with a warning;
+// a missing or unparseable "interval_minutes" falls back to
+// defaultFeedIntervalMinutes.
+func loadFeedInfos(dir string) ([]feedInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var feeds []feedInfo
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+
+		urlBytes, err := os.ReadFile(filepath.Join(dir, name, "url"))
+		if err != nil {
+			log.Printf("[research] rss: %s has no url file, skipping: %v", name, err)
+			continue
+		}
+		feedURL := strings.TrimSpace(string(urlBytes))
+		if feedURL == "" {
+			log.Printf("[research] rss: %s has an empty url file, skipping", name)
+			continue
+		}
+
+		interval := defaultFeedIntervalMinutes
+		if raw, err := os.ReadFile(filepath.Join(dir, name, "interval_minutes")); err == nil {
+			if n, err := strconv.Atoi(strings.TrimSpace(string(raw))); err == nil && n > 0 {
+				interval = n
+			}
+		}
+
+		feeds = append(feeds, feedInfo{
+			Name:     name,
+			URL:      feedURL,
+			Interval: time.Duration(interval) * time.Minute,
+		})
+	}
+	return feeds, nil
+}
+
+// --- Per-feed polling state ---
+
+// loadFeedState reads the last-fetched timestamp per feed name from path,
+// returning an empty map if it doesn't exist yet or fails to parse.
+func loadFeedState(path string) map[string]time.Time {
+	state := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	_ = json.Unmarshal(data, &state)
+	return state
+}
+
+func saveFeedState(path string, state map[string]time.Time) {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,229 @@
+package research
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/httpcache"
+	"true-crime-pipeline/types"
+)
+
+// hookKeywords boost a story's score when present
+var hookKeywords = []string{
+	"missing", "murder", "betrayal", "unsolved", "conspiracy",
+	"identity", "disappeared", "cold case", "suspect", "arrested",
+	"convicted", "escaped", "secret", "revealed", "shocking",
+	"victim", "killer", "evidence", "confession", "cover-up",
+}
+
+// sourceTimeout bounds how long Run waits on any single Source's Fetch
+// before giving up on it, so one slow or dead feed can't stall the whole
+// research stage.
+const sourceTimeout = 20 * time.Second
+
+// Scraper holds all scraping dependencies
+type Scraper struct {
+	cfg         *config.Config
+	usedStories map[string]bool
+	sources     []Source
+	cache       *httpcache.Cache
+}
+
+// New creates a new Scraper, activating whichever Sources in the
+// package-level registry report themselves Enabled for cfg.
+func New(cfg *config.Config) *Scraper {
+	var active []Source
+	for _, src := range registry {
+		if src.Enabled(cfg) {
+			active = append(active, src)
+		}
+	}
+	return &Scraper{
+		cfg:         cfg,
+		usedStories: loadUsedStories(cfg.Paths.UsedStoriesLog),
+		sources:     active,
+		cache:       httpcache.New(cfg.Cache),
+	}
+}
+
+// CacheStats returns the shared HTTP response cache's hit/miss counts for
+// this Scraper's Sources, so a quota-limited Source like NewsAPI has a
+// visible budget instead of a silent 429 at the end of the month.
+func (s *Scraper) CacheStats() httpcache.Stats {
+	return s.cache.Stats()
+}
+
+// sourceResult is one Source's fan-out outcome, logged as a metrics line
+// once every Source has returned.
+type sourceResult struct {
+	name     string
+	stories  []*types.Story
+	err      error
+	duration time.Duration
+}
+
+// Run fans every active Source out concurrently (each bounded by
+// sourceTimeout), scores and deduplicates whatever comes back, and returns
+// the best story not already in usedStories.
+func (s *Scraper) Run(ctx context.Context) (*types.Story, error) {
+	log.Println("[research] Starting story scrape...")
+
+	if len(s.sources) == 0 {
+		return nil, fmt.Errorf("no research sources enabled")
+	}
+
+	ctx = httpcache.WithCache(ctx, s.cache)
+
+	results := make([]sourceResult, len(s.sources))
+	var wg sync.WaitGroup
+	for i, src := range s.sources {
+		i, src := i, src
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			srcCtx, cancel := context.WithTimeout(ctx, sourceTimeout)
+			defer cancel()
+
+			start := time.Now()
+			stories, err := src.Fetch(srcCtx, s.cfg)
+			results[i] = sourceResult{name: src.Name(), stories: stories, err: err, duration: time.Since(start)}
+		}()
+	}
+	wg.Wait()
+
+	if stats := s.cache.Stats(); stats.Hits+stats.Misses > 0 {
+		log.Printf("[research] http cache: %d hits, %d misses", stats.Hits, stats.Misses)
+	}
+
+	var candidates []*types.Story
+	for _, r := range results {
+		if r.err != nil {
+			log.Printf("[research] %s: error after %s: %v", r.name, r.duration.Round(time.Millisecond), r.err)
+			continue
+		}
+		log.Printf("[research] %s: found %d stories in %s", r.name, len(r.stories), r.duration.Round(time.Millisecond))
+		candidates = append(candidates, r.stories...)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no stories found from any source")
+	}
+
+	// Score and sort
+	for _, story := range candidates {
+		story.Score = scoreStory(story)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	// Pick top non-used story
+	for _, story := range candidates {
+		if !s.usedStories[story.ID] {
+			log.Printf("[research] ✅ Selected story: %q (score: %d)", story.Title, story.Score)
+			s.markUsed(story)
+			return story, nil
+		}
+	}
+
+	return nil, fmt.Errorf("all candidate stories have been used already")
+}
+
+// --- Scoring ---
+
+// scoreStory is also used by ArchiveImporter.Import so offline-imported
+// stories are ranked on the same scale as live Source results.
+func scoreStory(story *types.Story) int {
+	score := story.Score // base score from Reddit upvotes
+
+	// Keyword bonus
+	titleLower := strings.ToLower(story.Title + " " + story.Body)
+	for _, kw := range hookKeywords {
+		if strings.Contains(titleLower, kw) {
+			score += 50
+		}
+	}
+
+	// Has image bonus
+	if len(story.ImageURLs) > 0 {
+		score += 100
+	}
+
+	// Recency bonus: published within last 3 days
+	if t, err := time.Parse(time.RFC3339, story.PublishedAt); err == nil {
+		if time.Since(t) < 72*time.Hour {
+			score += 200
+		}
+	}
+
+	// Body length bonus (more content = better script material)
+	if len(story.Body) > 500 {
+		score += 75
+	}
+	if len(story.Body) > 1500 {
+		score += 75
+	}
+
+	return score
+}
+
+// --- Helpers ---
+func extractKeywords(text string) []string {
+	text = strings.ToLower(text)
+	var found []string
+	for _, kw := range hookKeywords {
+		if strings.Contains(text, kw) {
+			found = append(found, kw)
+		}
+	}
+	return found
+}
+
+func isImageURL(u string) bool {
+	lower := strings.ToLower(u)
+	return strings.HasSuffix(lower, ".jpg") ||
+		strings.HasSuffix(lower, ".jpeg") ||
+		strings.HasSuffix(lower, ".png") ||
+		strings.HasSuffix(lower, ".webp")
+}
+
+// --- Used stories dedup log ---
+func loadUsedStories(path string) map[string]bool {
+	used := make(map[string]bool)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return used
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return used
+	}
+	for _, id := range ids {
+		used[id] = true
+	}
+	return used
+}
+
+func (s *Scraper) markUsed(story *types.Story) {
+	markStoryUsed(s.cfg.Paths.UsedStoriesLog, s.usedStories, story.ID)
+}
+
+// markStoryUsed records id as used in used and persists used to path,
+// shared by Scraper.markUsed and ArchiveImporter.markUsed.
+func markStoryUsed(path string, used map[string]bool, id string) {
+	used[id] = true
+	var ids []string
+	for id := range used {
+		ids = append(ids, id)
+	}
+	data, _ := json.MarshalIndent(ids, "", "  ")
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,33 @@
+package research
+
+import (
+	"context"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/types"
+)
+
+// Source is one pluggable story feed. Implementations live in their own
+// file and self-register via RegisterSource from an init(), so adding a new
+// feed — an archived-case database, a court-record feed, a podcast
+// transcript source, YouTube trending — never requires touching Scraper.Run.
+type Source interface {
+	// Name identifies the source in logs, e.g. "reddit".
+	Name() string
+	// Enabled reports whether this source should run, based on cfg's
+	// enablement flag and any required credentials.
+	Enabled(cfg *config.Config) bool
+	// Fetch returns candidate stories. ctx carries the per-source timeout
+	// Scraper.Run applies around every call.
+	Fetch(ctx context.Context, cfg *config.Config) ([]*types.Story, error)
+}
+
+// registry holds every Source that has self-registered via RegisterSource.
+// Scraper.New walks it to build the active set for a given cfg.
+var registry []Source
+
+// RegisterSource adds a Source to the package-level registry. Call it from
+// an init() in the file that implements the Source.
+func RegisterSource(s Source) {
+	registry = append(registry, s)
+}
@@ -16,7 +16,7 @@ import (
 	"true-crime-pipeline/types"
 )
 
-const systemPrompt = `You are a professional true crime YouTube scriptwriter. You write gripping, cinematic scripts for faceless YouTube channels.
+const systemPromptBase = `You are a professional true crime YouTube scriptwriter. You write gripping, cinematic scripts for faceless YouTube channels.
 
 Your scripts MUST follow this exact structure:
 1. COLD OPEN (0:00-0:30) - Start with the most shocking fact. No context. Hook immediately.
@@ -42,6 +42,19 @@ Scene type rules:
 
 Keep total narration to 5-10 minutes when read aloud at natural pace (~130 words per minute).`
 
+// systemPromptFor appends a narration-language instruction to
+// systemPromptBase when language (a BCP-47 code like "es") is set, for
+// Localization's additional-language variants. image_prompt and
+// asset_tags stay untranslated either way since pipeline.go's
+// reuseVisualAssets overwrites them from the primary script's STAGE 4
+// output regardless of what the model returns here.
+func systemPromptFor(language string) string {
+	if language == "" {
+		return systemPromptBase
+	}
+	return systemPromptBase + fmt.Sprintf("\n\nWrite the narration in %s. Keep proper nouns in their original spelling.", language)
+}
+
 // Writer generates scripts using Groq API
 type Writer struct {
 	cfg        *config.Config
@@ -95,8 +108,10 @@ type sceneJSON struct {
 	ProofDurationSec float64  `json:"proof_duration_sec"`
 }
 
-// Run generates a full script from a story
-func (w *Writer) Run(ctx context.Context, story *types.Story) (*types.Script, error) {
+// Run generates a full script from a story, narrated in language (a
+// BCP-47 code like "es"); an empty language keeps this pipeline's default
+// English narration.
+func (w *Writer) Run(ctx context.Context, story *types.Story, language string) (*types.Script, error) {
 	log.Println("[script] Generating script via Groq (Llama 3)...")
 
 	apiKey := os.Getenv("GROQ_API_KEY")
@@ -109,7 +124,7 @@ func (w *Writer) Run(ctx context.Context, story *types.Story) (*types.Script, er
 	reqBody := groqRequest{
 		Model: w.cfg.Script.GroqModel,
 		Messages: []groqMessage{
-			{Role: "system", Content: systemPrompt},
+			{Role: "system", Content: systemPromptFor(language)},
 			{Role: "user", Content: userPrompt},
 		},
 		Temperature: w.cfg.Script.Temperature,
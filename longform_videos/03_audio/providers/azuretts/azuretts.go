@@ -0,0 +1,111 @@
+// Package azuretts speaks the Azure Cognitive Services Speech REST API
+// directly: mint a token, then POST SSML to the region's /cognitiveservices/v1
+// endpoint and get raw audio back. Azure's word-boundary events are only
+// delivered over the streaming Speech SDK (a websocket protocol, not plain
+// REST), so this provider's SynthesisResult.Words is always nil — subtitle
+// generation falls back to Whisper when Azure is the configured provider.
+package azuretts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/03_audio/providers"
+)
+
+const tokenPathFmt = "https://%s.api.cognitive.microsoft.com/sts/v1.0/issueToken"
+const synthesizePathFmt = "https://%s.tts.speech.microsoft.com/cognitiveservices/v1"
+
+// Provider synthesizes through the Azure Speech REST API.
+type Provider struct {
+	SubscriptionKey string
+	Region          string
+	HTTPClient      *http.Client
+}
+
+// New returns a Provider authenticated with subscriptionKey in region.
+func New(subscriptionKey, region string) *Provider {
+	return &Provider{
+		SubscriptionKey: subscriptionKey,
+		Region:          region,
+		HTTPClient:      &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (p *Provider) issueToken(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(tokenPathFmt, p.Region), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", p.SubscriptionKey)
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("azure issue token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("azure issue token: status %d: %s", resp.StatusCode, string(body))
+	}
+	return string(body), nil
+}
+
+func buildSSML(text, voiceName string) string {
+	return fmt.Sprintf(
+		`<speak version="1.0" xml:lang="en-US"><voice name="%s">%s</voice></speak>`,
+		voiceName, escapeXML(text),
+	)
+}
+
+func escapeXML(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	return s
+}
+
+func (p *Provider) Synthesize(ctx context.Context, text string, voice providers.VoiceSpec, out io.Writer) (providers.SynthesisResult, error) {
+	token, err := p.issueToken(ctx)
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+
+	voiceID := voice.VoiceID
+	if voiceID == "" {
+		voiceID = "en-US-GuyNeural"
+	}
+	ssml := buildSSML(text, voiceID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(synthesizePathFmt, p.Region), strings.NewReader(ssml))
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/ssml+xml")
+	req.Header.Set("X-Microsoft-OutputFormat", "audio-24khz-96kbitrate-mono-mp3")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("azure synthesize: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return providers.SynthesisResult{}, fmt.Errorf("azure synthesize: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	return providers.SynthesisResult{}, nil
+}
@@ -0,0 +1,13 @@
+// Package edgetts is a thin wrapper around the edge-tts CLI (free Microsoft
+// TTS), kept as the zero-config fallback when no other provider is set.
+package edgetts
+
+import (
+	"true-crime-pipeline/03_audio/providers"
+	"true-crime-pipeline/03_audio/providers/execcli"
+)
+
+// New returns a Provider that shells out to edge-tts.
+func New() providers.Provider {
+	return execcli.New("edge-tts")
+}
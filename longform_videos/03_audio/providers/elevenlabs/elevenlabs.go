@@ -0,0 +1,149 @@
+// Package elevenlabs speaks the ElevenLabs text-to-speech HTTP API directly
+// (no subprocess), using the "with-timestamps" endpoint so Synthesize can
+// return per-word alignment alongside the audio.
+package elevenlabs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/03_audio/providers"
+)
+
+const baseURL = "https://api.elevenlabs.io/v1/text-to-speech"
+
+// Provider synthesizes through the ElevenLabs API.
+type Provider struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// New returns a Provider authenticated with apiKey.
+func New(apiKey string) *Provider {
+	return &Provider{APIKey: apiKey, HTTPClient: &http.Client{Timeout: 60 * time.Second}}
+}
+
+type requestBody struct {
+	Text          string                 `json:"text"`
+	ModelID       string                 `json:"model_id,omitempty"`
+	VoiceSettings map[string]interface{} `json:"voice_settings,omitempty"`
+}
+
+type responseBody struct {
+	AudioBase64 string `json:"audio_base64"`
+	Alignment   struct {
+		Characters             []string  `json:"characters"`
+		CharacterStartTimesSec []float64 `json:"character_start_times_seconds"`
+		CharacterEndTimesSec   []float64 `json:"character_end_times_seconds"`
+	} `json:"alignment"`
+}
+
+func (p *Provider) Synthesize(ctx context.Context, text string, voice providers.VoiceSpec, out io.Writer) (providers.SynthesisResult, error) {
+	voiceID := voice.VoiceID
+	if voiceID == "" {
+		return providers.SynthesisResult{}, fmt.Errorf("elevenlabs: voice ID is required")
+	}
+
+	reqBody := requestBody{
+		Text:    text,
+		ModelID: voice.Model,
+		VoiceSettings: map[string]interface{}{
+			"stability":         voice.Stability,
+			"style":             voice.Style,
+			"use_speaker_boost": true,
+		},
+	}
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+
+	url := fmt.Sprintf("%s/%s/with-timestamps", baseURL, voiceID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	req.Header.Set("xi-api-key", p.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("elevenlabs request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return providers.SynthesisResult{}, fmt.Errorf("elevenlabs: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var decoded responseBody
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("elevenlabs: parse response: %w", err)
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(decoded.AudioBase64)
+	if err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("elevenlabs: decode audio: %w", err)
+	}
+	if _, err := out.Write(audio); err != nil {
+		return providers.SynthesisResult{}, err
+	}
+
+	words := wordsFromCharacterAlignment(decoded.Alignment.Characters,
+		decoded.Alignment.CharacterStartTimesSec, decoded.Alignment.CharacterEndTimesSec)
+
+	var duration float64
+	if len(words) > 0 {
+		duration = words[len(words)-1].End
+	}
+
+	return providers.SynthesisResult{DurationSec: duration, Words: words}, nil
+}
+
+// wordsFromCharacterAlignment groups ElevenLabs's per-character timestamps
+// into per-word timestamps, splitting on whitespace.
+func wordsFromCharacterAlignment(chars []string, starts, ends []float64) []providers.WordTiming {
+	var words []providers.WordTiming
+	var cur strings.Builder
+	var wordStart float64
+	inWord := false
+
+	flush := func(wordEnd float64) {
+		if cur.Len() == 0 {
+			return
+		}
+		words = append(words, providers.WordTiming{Word: cur.String(), Start: wordStart, End: wordEnd})
+		cur.Reset()
+		inWord = false
+	}
+
+	for i, ch := range chars {
+		if i >= len(starts) || i >= len(ends) {
+			break
+		}
+		if strings.TrimSpace(ch) == "" {
+			flush(ends[i])
+			continue
+		}
+		if !inWord {
+			wordStart = starts[i]
+			inWord = true
+		}
+		cur.WriteString(ch)
+		if i == len(chars)-1 {
+			flush(ends[i])
+		}
+	}
+	return words
+}
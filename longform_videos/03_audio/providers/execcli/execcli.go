@@ -0,0 +1,77 @@
+// Package execcli is the subprocess-based TTS provider: it shells out to a
+// CLI that accepts --text/--output flags (or edge-tts's --write-media, or a
+// custom .py script), the three shapes audio.Generator used to hardcode
+// before providers existed. Kept for setups that still set TTS_COMMAND.
+package execcli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"true-crime-pipeline/03_audio/providers"
+)
+
+// Provider shells out to Cmd for each Synthesize call.
+type Provider struct {
+	Cmd string
+}
+
+// New returns a Provider that runs cmd as described above.
+func New(cmd string) *Provider {
+	return &Provider{Cmd: strings.TrimSpace(cmd)}
+}
+
+func (p *Provider) Synthesize(ctx context.Context, text string, voice providers.VoiceSpec, out io.Writer) (providers.SynthesisResult, error) {
+	tmp, err := os.CreateTemp("", "tts-*.mp3")
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	var cmd *exec.Cmd
+	switch {
+	case p.Cmd == "edge-tts":
+		voiceID := voice.VoiceID
+		if voiceID == "" {
+			voiceID = "en-US-GuyNeural"
+		}
+		cmd = exec.CommandContext(ctx, "edge-tts",
+			"--voice", voiceID,
+			"--text", text,
+			"--write-media", tmpPath,
+		)
+
+	case strings.HasSuffix(p.Cmd, ".py"):
+		cmd = exec.CommandContext(ctx, "python3", p.Cmd,
+			"--text", text,
+			"--output", tmpPath,
+		)
+
+	default:
+		cmd = exec.CommandContext(ctx, p.Cmd,
+			"--text", text,
+			"--output", tmpPath,
+		)
+	}
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("exec tts: %w", err)
+	}
+
+	data, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("read tts output: %w", err)
+	}
+	if _, err := out.Write(data); err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	return providers.SynthesisResult{}, nil
+}
@@ -0,0 +1,81 @@
+// Package openaitts speaks OpenAI's /v1/audio/speech TTS API directly.
+// OpenAI's endpoint returns raw audio bytes only — no alignment data — so
+// SynthesisResult.Words is always nil and subtitle generation falls back
+// to Whisper for this provider.
+package openaitts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"true-crime-pipeline/03_audio/providers"
+)
+
+const endpoint = "https://api.openai.com/v1/audio/speech"
+
+// Provider synthesizes through the OpenAI TTS API.
+type Provider struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// New returns a Provider authenticated with apiKey.
+func New(apiKey string) *Provider {
+	return &Provider{APIKey: apiKey, HTTPClient: &http.Client{Timeout: 60 * time.Second}}
+}
+
+type requestBody struct {
+	Model          string `json:"model"`
+	Input          string `json:"input"`
+	Voice          string `json:"voice"`
+	ResponseFormat string `json:"response_format"`
+}
+
+func (p *Provider) Synthesize(ctx context.Context, text string, voice providers.VoiceSpec, out io.Writer) (providers.SynthesisResult, error) {
+	model := voice.Model
+	if model == "" {
+		model = "tts-1"
+	}
+	voiceName := voice.VoiceID
+	if voiceName == "" {
+		voiceName = "onyx"
+	}
+
+	payload, err := json.Marshal(requestBody{
+		Model:          model,
+		Input:          text,
+		Voice:          voiceName,
+		ResponseFormat: "mp3",
+	})
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("openai tts request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return providers.SynthesisResult{}, fmt.Errorf("openai tts: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	return providers.SynthesisResult{}, nil
+}
@@ -0,0 +1,65 @@
+// Package piper talks to a local Piper HTTP server (e.g. the wyoming-piper
+// http wrapper) running at BaseURL — no subprocess, no vendor API key.
+// Useful for fully offline/self-hosted TTS.
+package piper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/03_audio/providers"
+)
+
+// Provider synthesizes through a local Piper HTTP server.
+type Provider struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a Provider pointed at baseURL (e.g. "http://localhost:5000").
+func New(baseURL string) *Provider {
+	return &Provider{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+type requestBody struct {
+	Text  string `json:"text"`
+	Voice string `json:"voice,omitempty"`
+}
+
+func (p *Provider) Synthesize(ctx context.Context, text string, voice providers.VoiceSpec, out io.Writer) (providers.SynthesisResult, error) {
+	payload, err := json.Marshal(requestBody{Text: text, Voice: voice.VoiceID})
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.BaseURL+"/synthesize", bytes.NewReader(payload))
+	if err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return providers.SynthesisResult{}, fmt.Errorf("piper request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return providers.SynthesisResult{}, fmt.Errorf("piper: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return providers.SynthesisResult{}, err
+	}
+	return providers.SynthesisResult{}, nil
+}
@@ -0,0 +1,40 @@
+// Package providers defines the pluggable TTS backend that audio.Generator
+// synthesizes scene narration through. A Provider either speaks a vendor's
+// HTTP API directly (providers/elevenlabs, openaitts, azuretts, piper) or
+// shells out to a CLI for backwards compatibility with older TTS_COMMAND
+// setups (providers/edgetts, providers/execcli).
+package providers
+
+import (
+	"context"
+	"io"
+)
+
+// VoiceSpec selects which voice/model a Provider should synthesize with.
+type VoiceSpec struct {
+	VoiceID    string
+	Model      string
+	Style      float64
+	Stability  float64
+	SampleRate int
+}
+
+// WordTiming is one word's start/end time in seconds, relative to the start
+// of the synthesized clip.
+type WordTiming struct {
+	Word  string
+	Start float64
+	End   float64
+}
+
+// SynthesisResult describes what a Provider produced. Words is nil when the
+// provider doesn't return alignment data — callers fall back to Whisper.
+type SynthesisResult struct {
+	DurationSec float64
+	Words       []WordTiming
+}
+
+// Provider synthesizes text to speech, writing audio bytes to out.
+type Provider interface {
+	Synthesize(ctx context.Context, text string, voice VoiceSpec, out io.Writer) (SynthesisResult, error)
+}
@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"time"
+)
+
+// retrying wraps a Provider with exponential backoff retries, replacing the
+// ad-hoc "retry up to 3 times" loop that used to live inline in
+// audio.Generator.generateSceneAudio — now shared by every provider.
+type retrying struct {
+	inner     Provider
+	attempts  int
+	baseDelay time.Duration
+}
+
+// WithRetry wraps inner so failed Synthesize calls are retried up to attempts
+// times with baseDelay*attempt backoff between tries. attempts <= 0 defaults
+// to 3; baseDelay <= 0 defaults to 2s.
+func WithRetry(inner Provider, attempts int, baseDelay time.Duration) Provider {
+	if attempts <= 0 {
+		attempts = 3
+	}
+	if baseDelay <= 0 {
+		baseDelay = 2 * time.Second
+	}
+	return &retrying{inner: inner, attempts: attempts, baseDelay: baseDelay}
+}
+
+func (r *retrying) Synthesize(ctx context.Context, text string, voice VoiceSpec, out io.Writer) (SynthesisResult, error) {
+	var lastErr error
+	for attempt := 1; attempt <= r.attempts; attempt++ {
+		var buf bytes.Buffer
+		res, err := r.inner.Synthesize(ctx, text, voice, &buf)
+		if err == nil {
+			if _, werr := out.Write(buf.Bytes()); werr != nil {
+				return SynthesisResult{}, werr
+			}
+			return res, nil
+		}
+		lastErr = err
+		if attempt == r.attempts {
+			break
+		}
+		log.Printf("[audio] TTS attempt %d/%d failed: %v — retrying...", attempt, r.attempts, err)
+		select {
+		case <-ctx.Done():
+			return SynthesisResult{}, ctx.Err()
+		case <-time.After(r.baseDelay * time.Duration(attempt)):
+		}
+	}
+	return SynthesisResult{}, lastErr
+}
@@ -0,0 +1,288 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"true-crime-pipeline/03_audio/providers"
+	"true-crime-pipeline/03_audio/providers/azuretts"
+	"true-crime-pipeline/03_audio/providers/edgetts"
+	"true-crime-pipeline/03_audio/providers/elevenlabs"
+	"true-crime-pipeline/03_audio/providers/execcli"
+	"true-crime-pipeline/03_audio/providers/openaitts"
+	"true-crime-pipeline/03_audio/providers/piper"
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/media"
+	"true-crime-pipeline/media/validate"
+	"true-crime-pipeline/progress"
+	"true-crime-pipeline/types"
+)
+
+// Generator handles TTS audio generation
+type Generator struct {
+	cfg      *config.Config
+	reporter progress.Reporter
+}
+
+// New creates a new Generator. reporter may be nil, in which case progress
+// events are discarded.
+func New(cfg *config.Config, reporter progress.Reporter) *Generator {
+	if reporter == nil {
+		reporter = progress.NopReporter{}
+	}
+	return &Generator{cfg: cfg, reporter: reporter}
+}
+
+// newProvider builds the configured providers.Provider, wrapped with retry
+// backoff. AudioConfig.Provider selects the backend; an empty value falls
+// back to edge-tts, the same zero-config default Generator always had.
+func (g *Generator) newProvider() (providers.Provider, providers.VoiceSpec, error) {
+	a := g.cfg.Audio
+	switch strings.ToLower(a.Provider) {
+	case "", "edgetts":
+		return providers.WithRetry(edgetts.New(), 3, 2*time.Second), providers.VoiceSpec{}, nil
+
+	case "elevenlabs":
+		apiKey := os.Getenv(a.ElevenLabs.APIKeyEnv)
+		if apiKey == "" {
+			return nil, providers.VoiceSpec{}, fmt.Errorf("elevenlabs: env var %q is not set", a.ElevenLabs.APIKeyEnv)
+		}
+		voice := providers.VoiceSpec{
+			VoiceID:   a.ElevenLabs.VoiceID,
+			Model:     a.ElevenLabs.ModelID,
+			Stability: a.ElevenLabs.Stability,
+			Style:     a.ElevenLabs.Style,
+		}
+		return providers.WithRetry(elevenlabs.New(apiKey), 3, 2*time.Second), voice, nil
+
+	case "openai":
+		apiKey := os.Getenv(a.OpenAI.APIKeyEnv)
+		if apiKey == "" {
+			return nil, providers.VoiceSpec{}, fmt.Errorf("openai: env var %q is not set", a.OpenAI.APIKeyEnv)
+		}
+		voice := providers.VoiceSpec{VoiceID: a.OpenAI.Voice, Model: a.OpenAI.Model}
+		return providers.WithRetry(openaitts.New(apiKey), 3, 2*time.Second), voice, nil
+
+	case "azure":
+		key := os.Getenv(a.Azure.SubscriptionKeyEnv)
+		if key == "" {
+			return nil, providers.VoiceSpec{}, fmt.Errorf("azure: env var %q is not set", a.Azure.SubscriptionKeyEnv)
+		}
+		voice := providers.VoiceSpec{VoiceID: a.Azure.VoiceName}
+		return providers.WithRetry(azuretts.New(key, a.Azure.Region), 3, 2*time.Second), voice, nil
+
+	case "piper":
+		voice := providers.VoiceSpec{VoiceID: a.Piper.Voice}
+		return providers.WithRetry(piper.New(a.Piper.BaseURL), 3, 2*time.Second), voice, nil
+
+	case "exec":
+		ttsCmd := os.Getenv("TTS_COMMAND")
+		if ttsCmd == "" {
+			return nil, providers.VoiceSpec{}, fmt.Errorf("exec provider selected but TTS_COMMAND is not set")
+		}
+		return providers.WithRetry(execcli.New(ttsCmd), 3, 2*time.Second), providers.VoiceSpec{}, nil
+
+	default:
+		return nil, providers.VoiceSpec{}, fmt.Errorf("unknown audio provider %q", a.Provider)
+	}
+}
+
+// Run generates audio for every scene in the script, up to Concurrency
+// scenes at once, via the configured Provider.
+func (g *Generator) Run(ctx context.Context, script *types.Script, outputDir string) error {
+	log.Println("[audio] Generating TTS audio for all scenes...")
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("create audio dir: %w", err)
+	}
+
+	provider, voice, err := g.newProvider()
+	if err != nil {
+		// Fall back to the always-available edge-tts rather than hard-failing
+		// a run over a misconfigured premium provider.
+		if _, lookErr := exec.LookPath("edge-tts"); lookErr == nil {
+			log.Printf("[audio] Warning: %v — falling back to edge-tts", err)
+			provider = providers.WithRetry(edgetts.New(), 3, 2*time.Second)
+			voice = providers.VoiceSpec{}
+		} else {
+			return err
+		}
+	}
+
+	concurrency := g.cfg.Audio.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	prober := media.New(outputDir)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(script.Scenes))
+
+	for i := range script.Scenes {
+		i := i
+		scene := &script.Scenes[i]
+		outFile := filepath.Join(outputDir, fmt.Sprintf("scene_%03d.mp3", i))
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			log.Printf("[audio] Scene %d/%d: generating audio...", i+1, len(script.Scenes))
+			if err := g.generateSceneAudio(ctx, prober, provider, voice, scene, outFile); err != nil {
+				errs[i] = fmt.Errorf("scene %d TTS failed: %w", i, err)
+				return
+			}
+			log.Printf("[audio] Scene %d: %.2fs → %s", i, scene.AudioDurationSec, outFile)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	// Recalculate timestamps from real audio durations
+	recalcTimestamps(script)
+
+	// Concatenate all segments into one final audio file
+	finalAudio := filepath.Join(outputDir, "audio_final.mp3")
+	if err := g.concatenateAudio(ctx, script, outputDir, finalAudio); err != nil {
+		return fmt.Errorf("concatenate audio: %w", err)
+	}
+
+	log.Printf("[audio] ✅ Final audio: %s (total: %.1fs)", finalAudio, script.TotalSec)
+	return nil
+}
+
+// generateSceneAudio synthesizes one scene's narration through provider,
+// retrying up to Validation.MaxRetries times if the result fails
+// validate.Audio's duration check against the word-count estimate STAGE 2
+// set on scene.AudioDurationSec — catching a provider that hands back
+// truncated or looping audio. A scene still bad after retries is marked
+// Degraded and shipped with its last attempt rather than aborting the run.
+// It records the resulting duration and (if the provider returned any)
+// word timings on scene.
+func (g *Generator) generateSceneAudio(ctx context.Context, prober *media.Prober, provider providers.Provider, voice providers.VoiceSpec, scene *types.ScriptScene, outFile string) error {
+	expectedSec := scene.AudioDurationSec // STAGE 2's word-count estimate
+
+	retries := g.cfg.Validation.MaxRetries
+	if retries <= 0 {
+		retries = 2
+	}
+	tolerance := g.cfg.Validation.AudioDurationTolerancePct
+	if tolerance <= 0 {
+		tolerance = 0.15
+	}
+
+	// Synthesize into a scratch file per attempt rather than outFile
+	// directly — a later attempt that fails outright at the provider level
+	// must not truncate an earlier attempt that synthesized fine but only
+	// failed validation, which would otherwise throw away a shippable
+	// (if degraded) result.
+	attemptFile := outFile + ".attempt"
+	defer os.Remove(attemptFile)
+
+	var result providers.SynthesisResult
+	var synthedOK bool
+	var valErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		f, err := os.Create(attemptFile)
+		if err != nil {
+			return err
+		}
+		r, synthErr := provider.Synthesize(ctx, scene.Narration, voice, f)
+		if closeErr := f.Close(); closeErr != nil && synthErr == nil {
+			synthErr = closeErr
+		}
+		if synthErr != nil {
+			log.Printf("[audio] Scene %d: synthesis failed (attempt %d/%d): %v", scene.Index, attempt+1, retries+1, synthErr)
+			continue
+		}
+		if err := os.Rename(attemptFile, outFile); err != nil {
+			return err
+		}
+		result, synthedOK = r, true
+		if valErr = validate.Audio(ctx, prober, outFile, expectedSec, tolerance); valErr != nil {
+			log.Printf("[audio] Scene %d: generated audio failed validation (attempt %d/%d): %v", scene.Index, attempt+1, retries+1, valErr)
+			continue
+		}
+		valErr = nil
+		break
+	}
+	if !synthedOK {
+		return fmt.Errorf("tts synthesis failed after %d attempts", retries+1)
+	}
+	if valErr != nil {
+		scene.Degraded = true
+		log.Printf("[audio] Scene %d: still failing validation after %d attempts — shipping the last attempt: %v", scene.Index, retries+1, valErr)
+	}
+
+	dur := result.DurationSec
+	if dur <= 0 {
+		if meta, err := prober.Probe(ctx, outFile); err == nil {
+			dur = meta.Duration
+		} else {
+			log.Printf("[audio] Warning: could not measure duration for scene %d, using estimate", scene.Index)
+		}
+	}
+	scene.AudioDurationSec = dur
+	scene.AudioFile = outFile
+
+	if len(result.Words) > 0 {
+		words := make([]types.WordTiming, len(result.Words))
+		for i, w := range result.Words {
+			words[i] = types.WordTiming{Word: w.Word, Start: w.Start, End: w.End}
+		}
+		scene.WordTimings = words
+	}
+
+	return nil
+}
+
+// concatenateAudio uses ffmpeg to join all scene audio files in order
+func (g *Generator) concatenateAudio(ctx context.Context, script *types.Script, audioDir, outputFile string) error {
+	// Build ffmpeg concat list
+	listFile := filepath.Join(audioDir, "concat_list.txt")
+	var lines []string
+	for _, scene := range script.Scenes {
+		if scene.AudioFile != "" {
+			lines = append(lines, fmt.Sprintf("file '%s'", scene.AudioFile))
+		}
+	}
+
+	if err := os.WriteFile(listFile, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return err
+	}
+
+	return progress.RunFFmpeg(ctx, "audio_concat", 0, script.TotalSec, g.reporter,
+		"-f", "concat",
+		"-safe", "0",
+		"-i", listFile,
+		"-c", "copy",
+		outputFile,
+	)
+}
+
+// recalcTimestamps updates scene timestamps based on real measured audio durations
+func recalcTimestamps(script *types.Script) {
+	var elapsed float64
+	for i := range script.Scenes {
+		script.Scenes[i].TimestampStart = elapsed
+		elapsed += script.Scenes[i].AudioDurationSec
+		script.Scenes[i].TimestampEnd = elapsed
+	}
+	script.TotalSec = elapsed
+}
@@ -48,10 +48,6 @@ func (am *AssetManager) Pick(scene *types.ScriptScene) (string, error) {
 	}
 
 	// Score every clip against the scene's asset tags
-	type scored struct {
-		file  string
-		score int
-	}
 	var candidates []scored
 
 	for file, clipTags := range am.tags {
@@ -119,10 +115,13 @@ func matchScore(required []string, clipTags []string, mood string) int {
 	return score
 }
 
-func sortScored(s []struct {
+// scored pairs a candidate clip filename with its matchScore result.
+type scored struct {
 	file  string
 	score int
-}) {
+}
+
+func sortScored(s []scored) {
 	for i := 1; i < len(s); i++ {
 		for j := i; j > 0 && s[j].score > s[j-1].score; j-- {
 			s[j], s[j-1] = s[j-1], s[j]
@@ -0,0 +1,64 @@
+package visuals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSortScoredDescending(t *testing.T) {
+	s := []scored{{file: "a", score: 5}, {file: "b", score: 20}, {file: "c", score: 10}}
+	sortScored(s)
+	want := []string{"b", "c", "a"}
+	for i, w := range want {
+		if s[i].file != w {
+			t.Fatalf("sortScored order = %v, want files in order %v", s, want)
+		}
+	}
+}
+
+func TestMatchScoreRequiredTagsAndMoodBonus(t *testing.T) {
+	score := matchScore([]string{"crime-scene", "night"}, []string{"Crime-Scene", "Night", "rain"}, "tense")
+	if score != 20 {
+		t.Fatalf("score = %d, want 20 (two matched required tags, no mood match)", score)
+	}
+
+	score = matchScore([]string{"crime-scene"}, []string{"crime-scene", "tense"}, "tense")
+	if score != 25 {
+		t.Fatalf("score = %d, want 25 (one required tag + mood bonus)", score)
+	}
+}
+
+func TestLoadTagsJSONSkipsUnderscoreKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tags.json")
+	data := `{
+		"_instructions": "don't use me",
+		"clip_a.mp4": ["night", "tense"],
+		"clip_b.mp4": ["day"]
+	}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	tags, err := loadTagsJSON(path)
+	if err != nil {
+		t.Fatalf("loadTagsJSON: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("got %d tag entries, want 2 (the _instructions key should be skipped): %v", len(tags), tags)
+	}
+	if _, ok := tags["_instructions"]; ok {
+		t.Fatalf("loadTagsJSON should have skipped the _instructions key")
+	}
+}
+
+func TestLoadTagsJSONMissingFileReturnsEmptyMap(t *testing.T) {
+	tags, err := loadTagsJSON(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("loadTagsJSON: %v", err)
+	}
+	if len(tags) != 0 {
+		t.Fatalf("expected an empty map for a missing tags file, got %v", tags)
+	}
+}
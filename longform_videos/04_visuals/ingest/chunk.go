@@ -0,0 +1,125 @@
+package ingest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// sceneCutRE matches ffmpeg showinfo's per-frame log line, pulling out the
+// frame's presentation timestamp.
+var sceneCutRE = regexp.MustCompile(`pts_time:([0-9.]+)`)
+
+// detectSceneCuts runs ffmpeg's scene-change detector over srcVideo and
+// returns the timestamp (seconds) of every frame whose scene score exceeds
+// threshold — i.e. every detected cut.
+func detectSceneCuts(ctx context.Context, srcVideo string, threshold float64) ([]float64, error) {
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-i", srcVideo,
+		"-vf", fmt.Sprintf("select='gt(scene,%.2f)',showinfo", threshold),
+		"-f", "null", "-",
+	)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var cuts []float64
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		m := sceneCutRE.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		if t, err := strconv.ParseFloat(m[1], 64); err == nil {
+			cuts = append(cuts, t)
+		}
+	}
+	_ = cmd.Wait() // ffmpeg -f null exits non-zero on some inputs even when showinfo ran fine; cuts is what matters
+
+	return cuts, nil
+}
+
+// segmentsFromCuts turns scene-cut timestamps across a video of totalSec
+// into a list of [start,end) segments each between minSec and maxSec:
+// consecutive cuts closer together than minSec are merged forward, and any
+// gap longer than maxSec is split into maxSec-sized pieces.
+func segmentsFromCuts(cuts []float64, totalSec, minSec, maxSec float64) [][2]float64 {
+	bounds := append([]float64{0}, cuts...)
+	bounds = append(bounds, totalSec)
+
+	var segments [][2]float64
+	start := bounds[0]
+	for i := 1; i < len(bounds); i++ {
+		end := bounds[i]
+		if end-start < minSec && i != len(bounds)-1 {
+			continue // merge forward into the next cut
+		}
+		for end-start > maxSec {
+			segments = append(segments, [2]float64{start, start + maxSec})
+			start += maxSec
+		}
+		if end-start >= minSec || i == len(bounds)-1 {
+			segments = append(segments, [2]float64{start, end})
+		}
+		start = end
+	}
+	return segments
+}
+
+// sceneChunks splits srcVideo into minSec–maxSec sub-clips at its
+// scene-cut boundaries, writing each chunk into destDir as "<stem>_NNN.mp4".
+func sceneChunks(ctx context.Context, srcVideo, destDir string, minSec, maxSec, threshold float64) ([]string, error) {
+	totalSec, err := probeDuration(ctx, srcVideo)
+	if err != nil {
+		return nil, fmt.Errorf("probe duration: %w", err)
+	}
+	if totalSec < minSec {
+		return nil, fmt.Errorf("source clip %s is shorter than the %.0fs minimum chunk length", srcVideo, minSec)
+	}
+
+	cuts, err := detectSceneCuts(ctx, srcVideo, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("detect scene cuts: %w", err)
+	}
+
+	segments := segmentsFromCuts(cuts, totalSec, minSec, maxSec)
+	stem := stemOf(srcVideo)
+
+	var chunks []string
+	for i, seg := range segments {
+		start, end := seg[0], seg[1]
+		if end-start < minSec {
+			continue // trailing sliver shorter than the minimum — drop it
+		}
+		outFile := filepath.Join(destDir, fmt.Sprintf("%s_%03d.mp4", stem, i))
+		cmd := exec.CommandContext(ctx, "ffmpeg", "-y",
+			"-ss", fmt.Sprintf("%.3f", start),
+			"-i", srcVideo,
+			"-t", fmt.Sprintf("%.3f", end-start),
+			"-c:v", "libx264",
+			"-preset", "fast",
+			"-crf", "23",
+			"-an",
+			outFile,
+		)
+		if err := cmd.Run(); err != nil {
+			return chunks, fmt.Errorf("cut chunk %d of %s: %w", i, srcVideo, err)
+		}
+		chunks = append(chunks, outFile)
+	}
+	return chunks, nil
+}
+
+func stemOf(path string) string {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	return base[:len(base)-len(ext)]
+}
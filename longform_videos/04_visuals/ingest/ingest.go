@@ -0,0 +1,158 @@
+// Package ingest backfills AssetManager's hand-curated assets/video
+// library from a list of B-roll search queries, instead of requiring every
+// clip to be manually sourced, trimmed, and tagged. It drives yt-dlp to
+// pull Creative-Commons-licensed footage, ffmpeg to cut each source into
+// scene-detected sub-clips, ffprobe to validate them, and appends the
+// survivors to tags.json with tags derived from the query plus the clip's
+// own measured brightness/dominant color. See the `ingest` pipeline
+// subcommand.
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"true-crime-pipeline/config"
+)
+
+const (
+	defaultMinSec         = 4.0
+	defaultMaxSec         = 10.0
+	defaultSceneThreshold = 0.4
+	minWidth              = 1920
+	minHeight             = 1080
+	maxResultsPerQuery    = 10 // yt-dlp search depth per query, before chunking
+)
+
+// Ingester drives one B-roll backfill run.
+type Ingester struct {
+	cfg      *config.Config
+	proxies  *ProxyPool
+	videoDir string
+	tagsPath string
+}
+
+// New builds an Ingester from cfg's Ingest/Paths settings.
+func New(cfg *config.Config) *Ingester {
+	return &Ingester{
+		cfg:      cfg,
+		proxies:  NewProxyPool(cfg.Ingest.Proxies, cfg.Ingest.ProxyCooldownMin),
+		videoDir: cfg.Paths.AssetsVideo,
+		tagsPath: cfg.Paths.VideoTags,
+	}
+}
+
+// Run downloads and tags up to n clips spread across queries, stopping
+// early once n is reached. It logs and skips a query that fails outright
+// (yt-dlp error, no CC results) rather than aborting the whole backfill.
+func (ig *Ingester) Run(ctx context.Context, queries []string, n int) error {
+	if err := os.MkdirAll(ig.videoDir, 0755); err != nil {
+		return fmt.Errorf("create assets dir: %w", err)
+	}
+	// Staged inside ig.videoDir itself (not the OS default temp dir) so the
+	// os.Rename below is same-filesystem — renaming across a tmpfs/volume
+	// boundary fails with "invalid cross-device link", which would silently
+	// drop every accepted clip. Same idiom as tts_generator.go's outFile +
+	// ".attempt" and video_generator.go's cacheFile + ".tmp".
+	stagingDir, err := os.MkdirTemp(ig.videoDir, "ingest-staging-*")
+	if err != nil {
+		return fmt.Errorf("create staging dir: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	minSec := ig.cfg.Ingest.ClipMinSec
+	if minSec <= 0 {
+		minSec = defaultMinSec
+	}
+	maxSec := ig.cfg.Ingest.ClipMaxSec
+	if maxSec <= 0 {
+		maxSec = defaultMaxSec
+	}
+	threshold := ig.cfg.Ingest.SceneThreshold
+	if threshold <= 0 {
+		threshold = defaultSceneThreshold
+	}
+
+	store, err := loadTagsStore(ig.tagsPath)
+	if err != nil {
+		return fmt.Errorf("load tags.json: %w", err)
+	}
+
+	added := 0
+	for _, query := range queries {
+		if added >= n {
+			break
+		}
+
+		log.Printf("[ingest] %q: searching yt-dlp...", query)
+		sources, err := ig.downloadQuery(ctx, query, maxResultsPerQuery, stagingDir)
+		if err != nil {
+			log.Printf("[ingest] %q: download failed: %v — skipping", query, err)
+			continue
+		}
+
+		for _, src := range sources {
+			if added >= n {
+				break
+			}
+
+			chunks, err := sceneChunks(ctx, src, stagingDir, minSec, maxSec, threshold)
+			if err != nil {
+				log.Printf("[ingest] %q: scene-detect chunking of %s failed: %v — skipping source", query, filepath.Base(src), err)
+				continue
+			}
+
+			for _, chunk := range chunks {
+				if added >= n {
+					break
+				}
+
+				meta, colorTag, err := validateClip(ctx, chunk, minSec, maxSec, minWidth, minHeight)
+				if err != nil {
+					log.Printf("[ingest] rejected %s: %v", filepath.Base(chunk), err)
+					continue
+				}
+
+				filename := fmt.Sprintf("%s%s", sanitizeFilename(query), filepath.Ext(chunk))
+				filename = uniqueFilename(ig.videoDir, filename)
+				if err := os.Rename(chunk, filepath.Join(ig.videoDir, filename)); err != nil {
+					log.Printf("[ingest] move %s into %s failed: %v", filepath.Base(chunk), ig.videoDir, err)
+					continue
+				}
+
+				tags := deriveTags(query, meta, colorTag)
+				if err := appendTag(store, filename, tags); err != nil {
+					log.Printf("[ingest] tag %s failed: %v", filename, err)
+					continue
+				}
+
+				log.Printf("[ingest] ✅ %s (%.1fs, tags: %v)", filename, meta.DurationSec, tags)
+				added++
+			}
+		}
+	}
+
+	if err := saveTagsStore(ig.tagsPath, store); err != nil {
+		return fmt.Errorf("save tags.json: %w", err)
+	}
+
+	log.Printf("[ingest] done: %d new clips added to %s", added, ig.videoDir)
+	return nil
+}
+
+// uniqueFilename returns name, or name with a numeric suffix inserted
+// before its extension, such that it doesn't already exist in dir.
+func uniqueFilename(dir, name string) string {
+	ext := filepath.Ext(name)
+	stem := name[:len(name)-len(ext)]
+	candidate := name
+	for i := 1; ; i++ {
+		if _, err := os.Stat(filepath.Join(dir, candidate)); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s_%d%s", stem, i, ext)
+	}
+}
@@ -0,0 +1,65 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProxyPoolEmptyReturnsNoAddr(t *testing.T) {
+	pool := NewProxyPool(nil, 15)
+	if got := pool.Next(); got != "" {
+		t.Fatalf("Next() = %q, want \"\" for an empty pool", got)
+	}
+}
+
+func TestProxyPoolRoundRobins(t *testing.T) {
+	pool := NewProxyPool([]string{"http://a", "http://b", "http://c"}, 15)
+	seen := []string{pool.Next(), pool.Next(), pool.Next()}
+	want := []string{"http://a", "http://b", "http://c"}
+	for i, w := range want {
+		if seen[i] != w {
+			t.Fatalf("round %d = %q, want %q (full sequence: %v)", i, seen[i], w, seen)
+		}
+	}
+	// The cycle wraps back to the first proxy.
+	if got := pool.Next(); got != "http://a" {
+		t.Fatalf("4th Next() = %q, want wraparound to %q", got, "http://a")
+	}
+}
+
+func TestProxyPoolCooldownBenchesAProxy(t *testing.T) {
+	pool := NewProxyPool([]string{"http://a", "http://b"}, 1)
+	pool.Cooldown("http://a")
+
+	// "a" is benched for a full minute, so every Next() should skip it and
+	// keep returning "b" until the pool is exhausted of non-cooling proxies.
+	for i := 0; i < 3; i++ {
+		if got := pool.Next(); got != "http://b" {
+			t.Fatalf("Next() round %d = %q, want %q (benched proxy should be skipped)", i, got, "http://b")
+		}
+	}
+}
+
+func TestProxyPoolCooldownUnknownAddrIsNoop(t *testing.T) {
+	pool := NewProxyPool([]string{"http://a"}, 15)
+	pool.Cooldown("http://not-in-pool")
+	if got := pool.Next(); got != "http://a" {
+		t.Fatalf("Next() = %q, want %q unaffected by an unknown Cooldown addr", got, "http://a")
+	}
+}
+
+func TestProxyPoolAllBenchedReturnsEmpty(t *testing.T) {
+	pool := NewProxyPool([]string{"http://a", "http://b"}, 15)
+	pool.Cooldown("http://a")
+	pool.Cooldown("http://b")
+	if got := pool.Next(); got != "" {
+		t.Fatalf("Next() = %q, want \"\" when every proxy is cooling down", got)
+	}
+}
+
+func TestProxyPoolDefaultsCooldownWhenNonPositive(t *testing.T) {
+	pool := NewProxyPool([]string{"http://a"}, 0)
+	if pool.cooldown != 15*time.Minute {
+		t.Fatalf("cooldown = %v, want the 15-minute default for cooldownMin <= 0", pool.cooldown)
+	}
+}
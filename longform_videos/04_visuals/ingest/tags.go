@@ -0,0 +1,114 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// firstFrameSignalStats runs ffmpeg's signalstats filter on srcVideo's
+// first frame and returns its printed lavfi.signalstats.* metadata keyed
+// by field name (e.g. "YAVG", "UAVG", "VAVG", "SATAVG").
+func firstFrameSignalStats(ctx context.Context, srcVideo string) (map[string]float64, error) {
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-i", srcVideo,
+		"-vf", "select=eq(n\\,0),signalstats,metadata=print:file=-",
+		"-frames:v", "1",
+		"-f", "null", "-",
+	)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg signalstats %s: %w", srcVideo, err)
+	}
+	return parseSignalStats(string(out)), nil
+}
+
+var signalStatsRE = regexp.MustCompile(`lavfi\.signalstats\.(\w+)=([\-0-9.]+)`)
+
+func parseSignalStats(output string) map[string]float64 {
+	stats := make(map[string]float64)
+	for _, m := range signalStatsRE.FindAllStringSubmatch(output, -1) {
+		if v, err := strconv.ParseFloat(m[2], 64); err == nil {
+			stats[m[1]] = v
+		}
+	}
+	return stats
+}
+
+// dominantColorTag buckets a frame's UAVG/VAVG chroma averages (ffmpeg's
+// signalstats, centered on 128) into a coarse hue name, or "grayscale" when
+// SATAVG indicates a near-desaturated frame.
+func dominantColorTag(stats map[string]float64) string {
+	if stats["SATAVG"] < 20 {
+		return "grayscale"
+	}
+
+	u, v := stats["UAVG"]-128, stats["VAVG"]-128
+	if u == 0 && v == 0 {
+		return "grayscale"
+	}
+
+	// atan2(V,U) maps chroma angle to hue the same way most YUV→hue charts
+	// do: +V is red-leaning, +U is blue-leaning.
+	angle := math.Atan2(v, u) * 180 / math.Pi
+	if angle < 0 {
+		angle += 360
+	}
+
+	switch {
+	case angle < 30 || angle >= 330:
+		return "red"
+	case angle < 90:
+		return "orange"
+	case angle < 150:
+		return "yellow-green"
+	case angle < 210:
+		return "blue-green"
+	case angle < 270:
+		return "blue"
+	default:
+		return "purple"
+	}
+}
+
+// brightnessTag buckets a 0-255 mean luma into the same coarse vocabulary
+// AssetManager.matchScore's mood matching expects ("dark", "normal", "bright").
+func brightnessTag(yavg float64) string {
+	switch {
+	case yavg < 70:
+		return "dark"
+	case yavg > 170:
+		return "bright"
+	default:
+		return "normal"
+	}
+}
+
+// deriveTags builds the tags.json entry for one ingested clip: the search
+// query's own words, plus brightness/color tags measured off the clip
+// itself.
+func deriveTags(query string, meta clipMeta, colorTag string) []string {
+	tags := strings.Fields(strings.ToLower(query))
+	tags = append(tags, brightnessTag(meta.Brightness))
+	if colorTag != "" {
+		tags = append(tags, colorTag)
+	}
+	return dedupe(tags)
+}
+
+func dedupe(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var out []string
+	for _, t := range tags {
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
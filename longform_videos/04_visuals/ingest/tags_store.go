@@ -0,0 +1,48 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// loadTagsStore reads tags.json into its raw key→value form, preserving
+// any "_instructions"/"_tag_options" bookkeeping keys AssetManager's own
+// loadTagsJSON skips, so appendTags can write them back untouched.
+func loadTagsStore(path string) (map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]json.RawMessage), nil
+		}
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return raw, nil
+}
+
+// appendTag adds (or overwrites) filename's tag list in store, keyed as
+// AssetManager.loadTagsJSON expects: a bare filename → []string entry
+// relative to Paths.AssetsVideo.
+func appendTag(store map[string]json.RawMessage, filename string, tags []string) error {
+	encoded, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+	store[filename] = encoded
+	return nil
+}
+
+// saveTagsStore writes store back to path. encoding/json sorts map keys
+// when marshaling, so repeat ingests produce a clean diff instead of
+// map-order churn.
+func saveTagsStore(path string, store map[string]json.RawMessage) error {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,96 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+// clipMeta is what Validate measures about a downloaded/chunked clip.
+type clipMeta struct {
+	DurationSec float64
+	Width       int
+	Height      int
+	// Brightness is the clip's mean luma (0-255, from ffmpeg's signalstats
+	// YAVG), sampled from its first frame.
+	Brightness float64
+}
+
+type ffprobeFormat struct {
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+	Streams []struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"streams"`
+}
+
+// probeDuration returns path's duration in seconds via ffprobe.
+func probeDuration(ctx context.Context, path string) (float64, error) {
+	meta, err := probe(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	return meta.DurationSec, nil
+}
+
+// probe runs ffprobe against path and returns its duration and video
+// resolution.
+func probe(ctx context.Context, path string) (clipMeta, error) {
+	cmd := exec.CommandContext(ctx, "ffprobe",
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height:format=duration",
+		"-of", "json",
+		path,
+	)
+	out, err := cmd.Output()
+	if err != nil {
+		return clipMeta{}, fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+
+	var parsed ffprobeFormat
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return clipMeta{}, fmt.Errorf("ffprobe %s: parse output: %w", path, err)
+	}
+	if len(parsed.Streams) == 0 {
+		return clipMeta{}, fmt.Errorf("ffprobe %s: no video stream", path)
+	}
+
+	duration, err := strconv.ParseFloat(parsed.Format.Duration, 64)
+	if err != nil {
+		return clipMeta{}, fmt.Errorf("ffprobe %s: parse duration: %w", path, err)
+	}
+
+	return clipMeta{
+		DurationSec: duration,
+		Width:       parsed.Streams[0].Width,
+		Height:      parsed.Streams[0].Height,
+	}, nil
+}
+
+// validateClip probes path and rejects it if its duration falls outside
+// [minSec, maxSec] or its resolution is below minWidth x minHeight, then
+// samples its first frame for brightness/dominant-color tagging.
+func validateClip(ctx context.Context, path string, minSec, maxSec float64, minWidth, minHeight int) (clipMeta, string, error) {
+	meta, err := probe(ctx, path)
+	if err != nil {
+		return clipMeta{}, "", err
+	}
+	if meta.DurationSec < minSec || meta.DurationSec > maxSec {
+		return clipMeta{}, "", fmt.Errorf("%s: duration %.1fs outside [%.0f,%.0f]", path, meta.DurationSec, minSec, maxSec)
+	}
+	if meta.Width < minWidth || meta.Height < minHeight {
+		return clipMeta{}, "", fmt.Errorf("%s: resolution %dx%d below %dx%d minimum", path, meta.Width, meta.Height, minWidth, minHeight)
+	}
+
+	stats, err := firstFrameSignalStats(ctx, path)
+	if err != nil {
+		return clipMeta{}, "", fmt.Errorf("measure brightness/color: %w", err)
+	}
+	meta.Brightness = stats["YAVG"]
+	return meta, dominantColorTag(stats), nil
+}
@@ -0,0 +1,94 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxProxyAttempts bounds how many proxies downloadQuery cycles through
+// before giving up on one search term.
+const maxProxyAttempts = 4
+
+// downloadQuery searches YouTube for query via yt-dlp's ytsearch, keeping
+// only Creative-Commons-licensed results, and downloads up to maxResults
+// clips at up to 1920x1080 into destDir. A download that draws an HTTP 429
+// benches the proxy that hit it (via ig.proxies) and retries on the next
+// one, up to maxProxyAttempts.
+func (ig *Ingester) downloadQuery(ctx context.Context, query string, maxResults int, destDir string) ([]string, error) {
+	stem := sanitizeFilename(query)
+	// Each query gets its own subdir rather than sharing destDir's
+	// namespace: two queries whose sanitized stems prefix one another
+	// (e.g. "dark forest" and "dark forest night") would otherwise glob
+	// up each other's leftover downloads.
+	queryDir, err := os.MkdirTemp(destDir, stem+"-")
+	if err != nil {
+		return nil, fmt.Errorf("create query staging dir: %w", err)
+	}
+	outTemplate := filepath.Join(queryDir, stem+"_%(id)s.%(ext)s")
+	searchTerm := fmt.Sprintf("ytsearch%d:%s", maxResults, query)
+
+	var lastErr error
+	for attempt := 0; attempt < maxProxyAttempts; attempt++ {
+		proxy := ig.proxies.Next()
+
+		args := []string{
+			searchTerm,
+			"--match-filter", "license*=Creative Commons",
+			"-f", "bestvideo[height<=1080][ext=mp4]/best[height<=1080][ext=mp4]",
+			"-S", "res:1080",
+			"-o", outTemplate,
+			"--no-playlist",
+			"--quiet",
+			"--no-warnings",
+		}
+		if proxy != "" {
+			args = append(args, "--proxy", proxy)
+		}
+
+		cmd := exec.CommandContext(ctx, "yt-dlp", args...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+		if err == nil {
+			return globDownloaded(queryDir)
+		}
+
+		lastErr = fmt.Errorf("yt-dlp %q: %w: %s", query, err, strings.TrimSpace(stderr.String()))
+		if proxy != "" && strings.Contains(stderr.String(), "429") {
+			ig.proxies.Cooldown(proxy)
+			continue
+		}
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("%w (exhausted %d proxy retries)", lastErr, maxProxyAttempts)
+}
+
+// globDownloaded returns every file yt-dlp wrote into dir.
+func globDownloaded(dir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil && !info.IsDir() {
+			files = append(files, m)
+		}
+	}
+	return files, nil
+}
+
+var nonAlnum = regexp.MustCompile(`[^a-z0-9]+`)
+
+// sanitizeFilename lowercases query and collapses runs of non-alphanumeric
+// characters to a single underscore, for use as a filename stem.
+func sanitizeFilename(query string) string {
+	s := nonAlnum.ReplaceAllString(strings.ToLower(query), "_")
+	return strings.Trim(s, "_")
+}
@@ -12,18 +12,24 @@ import (
 	"strings"
 	"time"
 
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/httpcache"
 	"true-crime-pipeline/types"
 )
 
 // PollinationsFetcher generates AI images via Pollinations.ai (free, no key needed)
 type PollinationsFetcher struct {
 	httpClient *http.Client
+	cache      *httpcache.Cache
 }
 
-// NewPollinationsFetcher creates a new fetcher
-func NewPollinationsFetcher() *PollinationsFetcher {
+// NewPollinationsFetcher creates a new fetcher. Identical prompt+seed+
+// dimensions produce the same Pollinations URL, so the shared httpcache
+// lets a rerun reuse the prior PNG instead of re-generating it.
+func NewPollinationsFetcher(cfg *config.Config) *PollinationsFetcher {
 	return &PollinationsFetcher{
 		httpClient: &http.Client{Timeout: 60 * time.Second},
+		cache:      httpcache.New(cfg.Cache),
 	}
 }
 
@@ -71,7 +77,7 @@ func (p *PollinationsFetcher) downloadImage(ctx context.Context, imageURL, outFi
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; TrueCrimePipeline/1.0)")
 
-	resp, err := p.httpClient.Do(req)
+	resp, err := p.cache.Do(p.httpClient, req)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,401 @@
+package visuals
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"true-crime-pipeline/04_visuals/webdriver"
+	"true-crime-pipeline/assetcache"
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/nlp"
+	"true-crime-pipeline/types"
+	"true-crime-pipeline/useragent"
+)
+
+// ProofScraper fetches real evidence images for proof scenes
+type ProofScraper struct {
+	renderer *webdriver.Renderer
+	cfg      *config.Config
+	sources  []ProofSource
+	assets   *assetcache.Store
+
+	headlessMu sync.RWMutex
+	// headlessOverride, when non-nil, takes priority over
+	// cfg.Visuals.HeadlessBrowser.Enabled — see EnableHeadless.
+	headlessOverride *bool
+
+	queryCacheMu sync.Mutex
+	// queryCache memoizes a ProofSource's Search results per (source name,
+	// query) pair across every scene this ProofScraper handles — since
+	// nlp-derived queries are now often identical across scenes naming the
+	// same person or place, without this every scene about the same
+	// suspect would re-search Wikipedia (etc.) for that name from scratch.
+	queryCache map[string][]Candidate
+
+	// sidecar, when cfg.Visuals.NLP.SidecarURL is set, is tried by
+	// entitiesFor before falling back to nlp.ExtractEntities' heuristic —
+	// nil keeps the heuristic as the only path.
+	sidecar *nlp.Sidecar
+}
+
+// NewProofScraper creates a new ProofScraper, registering every
+// newDefaultProofSources backend not explicitly Disabled via
+// Visuals.Proof.Sources. Its headless-browser fallback (see
+// webdriver.Renderer) activates only when
+// cfg.Visuals.HeadlessBrowser.Enabled is set. Every download (scene URL,
+// ProofSource candidate, story image) is routed through an assetcache.Store
+// so the same image reached via two different URLs or scenes is only ever
+// downloaded and stored once, behind a single shared useragent.Pool so
+// every scraped request rotates through the same realistic UA/header mix.
+func NewProofScraper(cfg *config.Config) *ProofScraper {
+	httpClient := &http.Client{Timeout: 20 * time.Second}
+	uaPool := useragent.NewPool(cfg.UserAgent, httpClient)
+	ps := &ProofScraper{
+		renderer:   webdriver.New(cfg.Visuals.HeadlessBrowser),
+		cfg:        cfg,
+		assets:     assetcache.NewStore(cfg.AssetCache, httpClient, uaPool),
+		queryCache: make(map[string][]Candidate),
+	}
+	if url := cfg.Visuals.NLP.SidecarURL; url != "" {
+		ps.sidecar = nlp.NewSidecar(url, time.Duration(cfg.Visuals.NLP.TimeoutSec)*time.Second)
+	}
+	for _, src := range newDefaultProofSources(cfg.Visuals.Proof, httpClient, uaPool) {
+		if override, ok := cfg.Visuals.Proof.Sources[src.Name()]; ok && override.Disabled {
+			continue
+		}
+		ps.Register(src)
+	}
+	return ps
+}
+
+// Register adds src to the set of ProofSource backends FetchProofImage
+// fans a query out to, for pipeline authors who want a custom source
+// without editing this package.
+func (ps *ProofScraper) Register(src ProofSource) {
+	ps.sources = append(ps.sources, src)
+}
+
+// Close shuts down the ProofScraper's shared headless Chrome instance.
+// Call it once on pipeline exit.
+func (ps *ProofScraper) Close() {
+	ps.renderer.Close()
+}
+
+// EnableHeadless overrides cfg.Visuals.HeadlessBrowser.Enabled at runtime,
+// for callers (e.g. a CLI flag, or a pipeline that wants to try headless
+// rendering only on a retry pass) that need to flip the headless-browser
+// fallback on or off without editing config.
+func (ps *ProofScraper) EnableHeadless(enabled bool) {
+	ps.headlessMu.Lock()
+	defer ps.headlessMu.Unlock()
+	ps.headlessOverride = &enabled
+	ps.renderer.SetEnabled(enabled)
+}
+
+// headlessEnabled reports whether the headless-browser fallback is active,
+// preferring headlessOverride (set via EnableHeadless) over the static
+// config value.
+func (ps *ProofScraper) headlessEnabled() bool {
+	ps.headlessMu.RLock()
+	defer ps.headlessMu.RUnlock()
+	if ps.headlessOverride != nil {
+		return *ps.headlessOverride
+	}
+	return ps.cfg.Visuals.HeadlessBrowser.Enabled
+}
+
+// FetchProofImage downloads a proof image for a scene, trying the scene's
+// existing URL first, then falling back to scraping. The returned Asset
+// carries a Blurhash callers can fall back to rendering as a low-resolution
+// placeholder if the downloaded file turns out corrupt later in the
+// pipeline.
+func (ps *ProofScraper) FetchProofImage(ctx context.Context, scene *types.ScriptScene, story *types.Story, outputDir string) (string, *assetcache.Asset, error) {
+	outFile := filepath.Join(outputDir, fmt.Sprintf("proof_%03d.jpg", scene.Index))
+
+	// Try scene's existing proof URL first
+	if scene.ProofImageURL != "" {
+		log.Printf("[proof] Scene %d: downloading from URL: %s", scene.Index, truncate(scene.ProofImageURL, 60))
+		if asset, err := ps.downloadFile(ctx, scene.ProofImageURL, outFile); err == nil {
+			return outFile, asset, nil
+		}
+		log.Printf("[proof] Scene %d: URL download failed, trying registered ProofSource backends", scene.Index)
+	}
+
+	// Fan the scene's narration out to every registered ProofSource
+	// concurrently, rank the combined candidates, and try downloading
+	// them best-first.
+	if img, asset, err := ps.searchSources(ctx, scene, story, outputDir); err == nil {
+		return img, asset, nil
+	}
+
+	// Try story's existing image URLs
+	for _, imgURL := range story.ImageURLs {
+		if asset, err := ps.downloadFile(ctx, imgURL, outFile); err == nil {
+			log.Printf("[proof] Scene %d: using story image: %s", scene.Index, truncate(imgURL, 60))
+			return outFile, asset, nil
+		}
+	}
+
+	// Every plain HTTP attempt above failed — before giving up, try
+	// rendering the story's source page in a headless browser, since many
+	// news sites, court-document viewers and archive pages only inject
+	// their evidence images via JavaScript.
+	if img, asset, err := ps.fetchViaHeadlessBrowser(ctx, scene, story, outputDir); err == nil {
+		return img, asset, nil
+	}
+
+	return "", nil, fmt.Errorf("no proof image found for scene %d", scene.Index)
+}
+
+// searchSources fans scene's query out to every registered ProofSource
+// concurrently (each bounded by Proof.TimeoutSec so one hung backend
+// can't block the others), scores the combined candidates (see
+// scoreCandidate), and attempts downloads best-first until one succeeds.
+func (ps *ProofScraper) searchSources(ctx context.Context, scene *types.ScriptScene, story *types.Story, outputDir string) (string, *assetcache.Asset, error) {
+	if len(ps.sources) == 0 {
+		return "", nil, fmt.Errorf("no ProofSource backends registered")
+	}
+	entities := ps.entitiesFor(scene)
+
+	timeout := proofSourceTimeout(ps.cfg.Visuals.Proof.TimeoutSec)
+	type result struct {
+		candidates []Candidate
+		err        error
+		name       string
+	}
+	results := make(chan result, len(ps.sources))
+	for _, src := range ps.sources {
+		src := src
+		query := queryFor(src.Name(), story, entities)
+		if query == "" {
+			results <- result{err: fmt.Errorf("no query extracted"), name: src.Name()}
+			continue
+		}
+		if cached, ok := ps.cachedCandidates(src.Name(), query); ok {
+			results <- result{candidates: cached, name: src.Name()}
+			continue
+		}
+		go func() {
+			srcCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			candidates, err := src.Search(srcCtx, query, 5)
+			if err == nil {
+				ps.cacheCandidates(src.Name(), query, candidates)
+			}
+			results <- result{candidates: candidates, err: err, name: src.Name()}
+		}()
+	}
+
+	var all []Candidate
+	for i := 0; i < len(ps.sources); i++ {
+		r := <-results
+		if r.err != nil {
+			log.Printf("[proof] Scene %d: source %q search failed: %v", scene.Index, r.name, r.err)
+			continue
+		}
+		all = append(all, r.candidates...)
+	}
+	if len(all) == 0 {
+		return "", nil, fmt.Errorf("no candidates found for scene %d", scene.Index)
+	}
+
+	allowlist := ps.cfg.Visuals.Proof.DomainAllowlist
+	sort.SliceStable(all, func(i, j int) bool {
+		return ps.weightedScore(all[i], allowlist) > ps.weightedScore(all[j], allowlist)
+	})
+
+	for i, c := range all {
+		if ps.weightedScore(c, allowlist) <= 0 {
+			continue // below scoreCandidate's minimum-resolution floor
+		}
+		outFile := filepath.Join(outputDir, fmt.Sprintf("proof_%03d_%s_%d.jpg", scene.Index, c.SourceName, i))
+		if asset, err := ps.downloadFile(ctx, c.URL, outFile); err == nil {
+			log.Printf("[proof] Scene %d: %s image found at %s", scene.Index, c.SourceName, truncate(c.URL, 60))
+			return outFile, asset, nil
+		}
+	}
+	return "", nil, fmt.Errorf("all %d candidates failed to download for scene %d", len(all), scene.Index)
+}
+
+// entitiesFor returns scene's cached nlp.Query, computing and caching it
+// on scene.Entities first if this is the first call for this scene — see
+// types.ScriptScene.Entities. When ps.sidecar is configured it's tried
+// first, falling back to the in-process nlp.ExtractEntities heuristic on
+// any sidecar error (a transient sidecar outage shouldn't stall the scene).
+func (ps *ProofScraper) entitiesFor(scene *types.ScriptScene) nlp.Query {
+	if scene.Entities != nil {
+		return *scene.Entities
+	}
+
+	entities, err := ps.extractEntities(scene.Narration)
+	if err != nil {
+		log.Printf("[proof] nlp sidecar failed for scene %d, falling back to heuristic: %v", scene.Index, err)
+		entities = nlp.ExtractEntities(scene.Narration)
+	}
+	scene.Entities = &entities
+	return entities
+}
+
+// extractEntities calls ps.sidecar if one's configured, otherwise returns
+// nlp.ExtractEntities' result directly (never erroring).
+func (ps *ProofScraper) extractEntities(narration string) (nlp.Query, error) {
+	if ps.sidecar == nil {
+		return nlp.ExtractEntities(narration), nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ps.sidecar.HTTPClient.Timeout)
+	defer cancel()
+	return ps.sidecar.ExtractEntities(ctx, narration)
+}
+
+// queryFor builds sourceName's targeted search query from story and
+// entities: Wikipedia gets the bare top PERSON (falling back to the top
+// LOC) since its opensearch/summary lookup wants an article title, not a
+// sentence; every other source gets story.Title plus that same top entity,
+// which disambiguates a generic name/place the way the old
+// extractSearchQuery's filler-word join never could. A scene whose
+// narration named no entities at all falls back to story.Title plus its
+// extracted Keywords.
+func queryFor(sourceName string, story *types.Story, entities nlp.Query) string {
+	top := entities.TopPerson()
+	if top == "" {
+		top = entities.TopPlace()
+	}
+	if top == "" {
+		return strings.TrimSpace(story.Title + " " + strings.Join(entities.Keywords, " "))
+	}
+	if sourceName == "wikipedia" {
+		return top
+	}
+	return strings.TrimSpace(story.Title + " " + top)
+}
+
+// cachedCandidates returns a prior Search result for (sourceName, query)
+// if one's already cached, so a later scene naming the same entity reuses
+// it instead of re-querying the backend.
+func (ps *ProofScraper) cachedCandidates(sourceName, query string) ([]Candidate, bool) {
+	ps.queryCacheMu.Lock()
+	defer ps.queryCacheMu.Unlock()
+	cached, ok := ps.queryCache[sourceName+"|"+query]
+	return cached, ok
+}
+
+func (ps *ProofScraper) cacheCandidates(sourceName, query string, candidates []Candidate) {
+	ps.queryCacheMu.Lock()
+	defer ps.queryCacheMu.Unlock()
+	ps.queryCache[sourceName+"|"+query] = candidates
+}
+
+// weightedScore is scoreCandidate with c's source's configured weight
+// (Visuals.Proof.Sources[c.SourceName].Weight, 0 meaning 1.0) applied.
+func (ps *ProofScraper) weightedScore(c Candidate, allowlist []string) float64 {
+	return scoreCandidate(c, allowlist, ps.cfg.Visuals.Proof.Sources[c.SourceName].Weight)
+}
+
+// fetchViaHeadlessBrowser tries each candidate evidence page — the scene's
+// own proof URL and the story's source URL — through downloadViaHeadless,
+// which checks ps.assets before paying Chrome's render cost again.
+func (ps *ProofScraper) fetchViaHeadlessBrowser(ctx context.Context, scene *types.ScriptScene, story *types.Story, outputDir string) (string, *assetcache.Asset, error) {
+	if !ps.headlessEnabled() {
+		return "", nil, fmt.Errorf("headless browser disabled")
+	}
+	for _, candidate := range []string{scene.ProofImageURL, story.SourceURL} {
+		if candidate == "" {
+			continue
+		}
+		asset, data, err := ps.downloadViaHeadless(ctx, candidate)
+		if err != nil {
+			log.Printf("[proof] Scene %d: headless render of %s failed: %v", scene.Index, truncate(candidate, 60), err)
+			continue
+		}
+
+		outFile := filepath.Join(outputDir, fmt.Sprintf("proof_%03d_headless.jpg", scene.Index))
+		if err := os.WriteFile(outFile, data, 0644); err != nil {
+			continue
+		}
+		log.Printf("[proof] Scene %d: headless-browser image captured from %s", scene.Index, truncate(candidate, 60))
+		return outFile, asset, nil
+	}
+	return "", nil, fmt.Errorf("headless browser found no image")
+}
+
+// downloadFile fetches fileURL through ps.assets (deduping identical bytes
+// reached via different URLs) and copies the cached file out to outPath, so
+// every other method here can keep using its own scene-indexed filename
+// instead of assetcache's content-addressed one. If the fetched asset turns
+// out to be HTML rather than an image — many evidence pages 200 a full
+// article instead of the bare image when hit without a browser session —
+// and the headless fallback is enabled, it escalates to webdriver.Renderer
+// against the same URL and caches whatever image that finds instead.
+// Accepted tradeoff: every candidate this is tried against (the scene URL,
+// each ranked ProofSource result, each story image) can independently pay
+// one headless-render timeout if it 200s HTML, so a scene whose top
+// several candidates all wrap their image in an HTML shell adds that many
+// renders before falling through to the single last-resort attempt in
+// fetchViaHeadlessBrowser — the same per-candidate-timeout shape
+// ProofScraper.searchSources already accepts for its own fan-out.
+func (ps *ProofScraper) downloadFile(ctx context.Context, fileURL, outPath string) (*assetcache.Asset, error) {
+	asset, err := ps.assets.Fetch(ctx, fileURL)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(asset.MIME, "text/html") && ps.headlessEnabled() {
+		if escalated, _, err := ps.downloadViaHeadless(ctx, fileURL); err == nil {
+			asset = escalated
+		} else {
+			log.Printf("[proof] %s returned HTML, headless escalation failed: %v", truncate(fileURL, 60), err)
+		}
+	}
+	data, err := os.ReadFile(asset.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// downloadViaHeadless returns pageURL's evidence image, preferring a cached
+// hit (under pageURL itself, aliased there by an earlier render that found
+// a genuine in-page image — see below) over paying Chrome's render cost —
+// or even a network round-trip — again. On a cache miss it renders pageURL
+// in the shared headless browser and extracts its largest in-viewport
+// image, caching the bytes under the image's resolved URL and aliasing
+// pageURL to the same entry so a later call for the same page short-circuits
+// on Lookup instead of re-rendering it. When webdriver.Renderer instead
+// falls back to a generic full-page/article screenshot (signaled by its
+// resolvedURL echoing pageURL — see Renderer.Fetch), that one-off result is
+// cached under a distinct key rather than pageURL itself, so a later scene
+// hitting the same page tries a fresh render instead of being stuck reusing
+// a screenshot that may have missed a lazy-loaded image on a slower load.
+func (ps *ProofScraper) downloadViaHeadless(ctx context.Context, pageURL string) (*assetcache.Asset, []byte, error) {
+	if cached, ok := ps.assets.Lookup(pageURL); ok && !strings.HasPrefix(cached.MIME, "text/html") {
+		if data, err := os.ReadFile(cached.Path); err == nil {
+			return cached, data, nil
+		}
+	}
+
+	data, resolvedURL, err := ps.renderer.Fetch(ctx, pageURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	mime := http.DetectContentType(data)
+	if resolvedURL == pageURL {
+		asset, err := ps.assets.StoreBytes(pageURL+"#screenshot", mime, data)
+		return asset, data, err
+	}
+	asset, err := ps.assets.StoreBytes(resolvedURL, mime, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	ps.assets.Alias(pageURL, asset)
+	return asset, data, nil
+}
@@ -0,0 +1,522 @@
+package visuals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/useragent"
+)
+
+// Candidate is one image result a ProofSource found for a query, before
+// any download is attempted — everything ProofScraper's scorer needs to
+// rank it against candidates from every other registered source.
+type Candidate struct {
+	URL        string
+	Width      int
+	Height     int
+	PageURL    string // where this image was found, for DomainAllowlist scoring
+	SourceName string
+}
+
+// ProofSource is a pluggable proof-image search backend. ProofScraper fans
+// a query out to every registered ProofSource concurrently (see
+// ProofScraper.searchSources) and ranks the combined candidates before
+// attempting downloads.
+type ProofSource interface {
+	Name() string
+	Search(ctx context.Context, query string, k int) ([]Candidate, error)
+}
+
+// wikipediaSource searches Wikipedia's REST summary API — needs no API
+// key, so it's always registered by default.
+type wikipediaSource struct {
+	httpClient *http.Client
+	uaPool     *useragent.Pool
+}
+
+func (s *wikipediaSource) Name() string { return "wikipedia" }
+
+func (s *wikipediaSource) Search(ctx context.Context, query string, k int) ([]Candidate, error) {
+	title, err := s.resolveTitle(ctx, query)
+	if err != nil {
+		// opensearch found no matching article title — still worth trying
+		// summary directly under the raw query, since it's not unheard of
+		// for the two endpoints to disagree on short/ambiguous titles.
+		title = query
+	}
+
+	searchURL := fmt.Sprintf(
+		"https://en.wikipedia.org/api/rest_v1/page/summary/%s",
+		url.PathEscape(title),
+	)
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	s.uaPool.Pick(ctx).Apply(req)
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("wikipedia returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Thumbnail struct {
+			Source string `json:"source"`
+			Width  int    `json:"width"`
+			Height int    `json:"height"`
+		} `json:"thumbnail"`
+		OriginalImage struct {
+			Source string `json:"source"`
+			Width  int    `json:"width"`
+			Height int    `json:"height"`
+		} `json:"originalimage"`
+		ContentURLs struct {
+			Desktop struct {
+				Page string `json:"page"`
+			} `json:"desktop"`
+		} `json:"content_urls"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	var candidates []Candidate
+	if result.OriginalImage.Source != "" {
+		candidates = append(candidates, Candidate{
+			URL: result.OriginalImage.Source, Width: result.OriginalImage.Width, Height: result.OriginalImage.Height,
+			PageURL: result.ContentURLs.Desktop.Page, SourceName: s.Name(),
+		})
+	}
+	if result.Thumbnail.Source != "" && result.Thumbnail.Source != result.OriginalImage.Source {
+		candidates = append(candidates, Candidate{
+			URL: result.Thumbnail.Source, Width: result.Thumbnail.Width, Height: result.Thumbnail.Height,
+			PageURL: result.ContentURLs.Desktop.Page, SourceName: s.Name(),
+		})
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no image in Wikipedia result for %q", query)
+	}
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	return candidates, nil
+}
+
+// resolveTitle looks up query's exact Wikipedia article title via the
+// action=opensearch API (e.g. "ted bundy" -> "Ted Bundy") — summary's own
+// endpoint 404s on anything short of an exact title match, so a query
+// built from nlp-extracted entities ("ted bundy", all-lowercase) needs
+// this resolution step first.
+func (s *wikipediaSource) resolveTitle(ctx context.Context, query string) (string, error) {
+	openSearchURL := fmt.Sprintf(
+		"https://en.wikipedia.org/w/api.php?action=opensearch&format=json&limit=1&search=%s",
+		url.QueryEscape(query),
+	)
+	req, _ := http.NewRequestWithContext(ctx, "GET", openSearchURL, nil)
+	s.uaPool.Pick(ctx).Apply(req)
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("wikipedia opensearch returned %d", resp.StatusCode)
+	}
+
+	// opensearch's response is the positional array
+	// [query, [titles...], [descriptions...], [urls...]], not an object.
+	var result []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if len(result) < 2 {
+		return "", fmt.Errorf("malformed opensearch response for %q", query)
+	}
+	var titles []string
+	if err := json.Unmarshal(result[1], &titles); err != nil || len(titles) == 0 {
+		return "", fmt.Errorf("no opensearch match for %q", query)
+	}
+	return titles[0], nil
+}
+
+// serpAPISource searches Google Images via SerpAPI; registered only when
+// SERPAPI_KEY is set.
+type serpAPISource struct {
+	httpClient *http.Client
+	apiKey     string
+	uaPool     *useragent.Pool
+}
+
+func (s *serpAPISource) Name() string { return "serpapi" }
+
+func (s *serpAPISource) Search(ctx context.Context, query string, k int) ([]Candidate, error) {
+	serpURL := fmt.Sprintf(
+		"https://serpapi.com/search.json?engine=google_images&q=%s&num=%d&api_key=%s",
+		url.QueryEscape(query), k, s.apiKey,
+	)
+	req, _ := http.NewRequestWithContext(ctx, "GET", serpURL, nil)
+	s.uaPool.Pick(ctx).Apply(req)
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		ImagesResults []struct {
+			Original       string `json:"original"`
+			OriginalWidth  int    `json:"original_width"`
+			OriginalHeight int    `json:"original_height"`
+			Source         string `json:"source"`
+			Link           string `json:"link"`
+		} `json:"images_results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.ImagesResults) == 0 {
+		return nil, fmt.Errorf("no Google Images results for %q", query)
+	}
+
+	candidates := make([]Candidate, 0, min(k, len(result.ImagesResults)))
+	for i, img := range result.ImagesResults {
+		if i >= k {
+			break
+		}
+		candidates = append(candidates, Candidate{
+			URL: img.Original, Width: img.OriginalWidth, Height: img.OriginalHeight,
+			PageURL: img.Link, SourceName: s.Name(),
+		})
+	}
+	return candidates, nil
+}
+
+// bingImageSource searches Bing's Image Search v7 API; registered only
+// when BING_SEARCH_KEY is set.
+type bingImageSource struct {
+	httpClient *http.Client
+	apiKey     string
+}
+
+func (s *bingImageSource) Name() string { return "bing" }
+
+func (s *bingImageSource) Search(ctx context.Context, query string, k int) ([]Candidate, error) {
+	searchURL := fmt.Sprintf(
+		"https://api.bing.microsoft.com/v7.0/images/search?q=%s&count=%d&safeSearch=Moderate",
+		url.QueryEscape(query), k,
+	)
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("bing returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Value []struct {
+			ContentURL  string `json:"contentUrl"`
+			HostPageURL string `json:"hostPageUrl"`
+			Width       int    `json:"width"`
+			Height      int    `json:"height"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return nil, fmt.Errorf("no Bing Images results for %q", query)
+	}
+
+	candidates := make([]Candidate, 0, len(result.Value))
+	for _, img := range result.Value {
+		candidates = append(candidates, Candidate{
+			URL: img.ContentURL, Width: img.Width, Height: img.Height,
+			PageURL: img.HostPageURL, SourceName: s.Name(),
+		})
+	}
+	return candidates, nil
+}
+
+// imgurSource searches Imgur's public gallery; registered only when
+// IMGUR_CLIENT_ID is set.
+type imgurSource struct {
+	httpClient *http.Client
+	clientID   string
+}
+
+func (s *imgurSource) Name() string { return "imgur" }
+
+func (s *imgurSource) Search(ctx context.Context, query string, k int) ([]Candidate, error) {
+	searchURL := fmt.Sprintf("https://api.imgur.com/3/gallery/search/time/all/0?q=%s", url.QueryEscape(query))
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Client-ID "+s.clientID)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("imgur returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data []struct {
+			Link   string `json:"link"`
+			Width  int    `json:"width"`
+			Height int    `json:"height"`
+			Images []struct {
+				Link   string `json:"link"`
+				Width  int    `json:"width"`
+				Height int    `json:"height"`
+			} `json:"images"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	var candidates []Candidate
+	for _, item := range result.Data {
+		if item.Link != "" {
+			candidates = append(candidates, Candidate{URL: item.Link, Width: item.Width, Height: item.Height, PageURL: item.Link, SourceName: s.Name()})
+		}
+		for _, img := range item.Images {
+			candidates = append(candidates, Candidate{URL: img.Link, Width: img.Width, Height: img.Height, PageURL: item.Link, SourceName: s.Name()})
+		}
+		if len(candidates) >= k {
+			break
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no Imgur results for %q", query)
+	}
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	return candidates, nil
+}
+
+// duckDuckGoSource scrapes DuckDuckGo's unofficial image search JSON
+// endpoint — there's no public DDG image API, so this needs no API key
+// and is registered by default, but (being undocumented) it's the
+// backend most likely to silently break if DuckDuckGo changes the
+// endpoint or its vqd token scheme.
+type duckDuckGoSource struct {
+	httpClient *http.Client
+	uaPool     *useragent.Pool
+}
+
+func (s *duckDuckGoSource) Name() string { return "duckduckgo" }
+
+func (s *duckDuckGoSource) Search(ctx context.Context, query string, k int) ([]Candidate, error) {
+	// One Pick for both requests below: a real browser session's vqd fetch
+	// and the i.js search that redeems it always share the same UA, so
+	// picking twice would itself be a scripted-traffic tell.
+	headers := s.uaPool.Pick(ctx)
+
+	vqd, err := s.fetchVQD(ctx, query, headers)
+	if err != nil {
+		return nil, fmt.Errorf("duckduckgo vqd token: %w", err)
+	}
+
+	searchURL := fmt.Sprintf(
+		"https://duckduckgo.com/i.js?q=%s&vqd=%s&o=json",
+		url.QueryEscape(query), url.QueryEscape(vqd),
+	)
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	headers.Apply(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("duckduckgo returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Results []struct {
+			Image  string `json:"image"`
+			URL    string `json:"url"`
+			Width  int    `json:"width"`
+			Height int    `json:"height"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Results) == 0 {
+		return nil, fmt.Errorf("no DuckDuckGo image results for %q", query)
+	}
+
+	candidates := make([]Candidate, 0, min(k, len(result.Results)))
+	for i, img := range result.Results {
+		if i >= k {
+			break
+		}
+		candidates = append(candidates, Candidate{URL: img.Image, Width: img.Width, Height: img.Height, PageURL: img.URL, SourceName: s.Name()})
+	}
+	return candidates, nil
+}
+
+// fetchVQD scrapes the "vqd" token DuckDuckGo's web UI embeds in its
+// regular HTML search results page — i.js rejects requests without one.
+// headers must be the same Headers the caller's subsequent i.js request
+// will use, since a real session never changes identity between the two.
+func (s *duckDuckGoSource) fetchVQD(ctx context.Context, query string, headers useragent.Headers) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		"https://duckduckgo.com/html/?q="+url.QueryEscape(query), nil)
+	if err != nil {
+		return "", err
+	}
+	headers.Apply(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+	if err != nil {
+		return "", err
+	}
+	body := string(data)
+
+	const marker = "vqd=\""
+	idx := indexOf(body, marker)
+	if idx < 0 {
+		return "", fmt.Errorf("vqd token not found")
+	}
+	rest := body[idx+len(marker):]
+	end := indexOf(rest, "\"")
+	if end < 0 {
+		return "", fmt.Errorf("malformed vqd token")
+	}
+	return rest[:end], nil
+}
+
+func indexOf(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return i
+		}
+	}
+	return -1
+}
+
+// newDefaultProofSources builds the candidate ProofSource set
+// NewProofScraper registers from: Wikipedia, DuckDuckGo and SearXNG always
+// (no API key needed — SearXNG is the free alternative to the paid
+// serpapi path below, so it's always in the running rather than requiring
+// an opt-in env var the way serpapi/bing/imgur do), the rest only when
+// their credential env var is set. NewProofScraper itself still filters
+// this list against ProofConfig.Sources' per-source Disabled override
+// before registering. uaPool is shared across every backend that scrapes
+// rather than calls an authenticated API, so they rotate through the same
+// pool instead of each holding their own cached browser-share snapshot.
+func newDefaultProofSources(cfg config.ProofConfig, httpClient *http.Client, uaPool *useragent.Pool) []ProofSource {
+	var sources []ProofSource
+	sources = append(sources, &wikipediaSource{httpClient: httpClient, uaPool: uaPool})
+	sources = append(sources, &duckDuckGoSource{httpClient: httpClient, uaPool: uaPool})
+	sources = append(sources, newSearxngSource(cfg.SearXNG, httpClient, uaPool))
+	if key := os.Getenv("SERPAPI_KEY"); key != "" {
+		sources = append(sources, &serpAPISource{httpClient: httpClient, apiKey: key, uaPool: uaPool})
+	}
+	if key := os.Getenv("BING_SEARCH_KEY"); key != "" {
+		sources = append(sources, &bingImageSource{httpClient: httpClient, apiKey: key})
+	}
+	if id := os.Getenv("IMGUR_CLIENT_ID"); id != "" {
+		sources = append(sources, &imgurSource{httpClient: httpClient, clientID: id})
+	}
+	return sources
+}
+
+// proofSourceTimeout returns cfg's per-source search timeout; 0 means 8s.
+func proofSourceTimeout(timeoutSec int) time.Duration {
+	if timeoutSec <= 0 {
+		timeoutSec = 8
+	}
+	return time.Duration(timeoutSec) * time.Second
+}
+
+// scoreCandidate ranks a Candidate for use as a Shorts proof image: below
+// a 640px minimum on its longer side it's rejected outright, otherwise it
+// scores higher the closer its aspect ratio is to 9:16 and higher again
+// if its page is on an allowlisted domain, all multiplied by the
+// candidate's source's configured weight. A candidate whose source didn't
+// report Width/Height (e.g. some Imgur/Bing results) skips the
+// resolution floor and aspect bonus rather than being penalized for
+// missing data — it downloads like any other unscored candidate, so a
+// backend that's stingy with metadata isn't starved of a chance to win.
+func scoreCandidate(c Candidate, allowlist []string, weight float64) float64 {
+	if weight <= 0 {
+		weight = 1.0
+	}
+
+	maxDim := c.Width
+	if c.Height > maxDim {
+		maxDim = c.Height
+	}
+	if maxDim > 0 && maxDim < 640 {
+		return 0
+	}
+
+	score := 1.0
+	if c.Width > 0 && c.Height > 0 {
+		const targetAspect = 9.0 / 16.0
+		aspect := float64(c.Width) / float64(c.Height)
+		diff := aspect - targetAspect
+		if diff < 0 {
+			diff = -diff
+		}
+		score += 1.0 - min(diff, 1.0)
+	}
+	if len(allowlist) > 0 {
+		if host := hostOf(c.PageURL); host != "" {
+			for _, allowed := range allowlist {
+				if allowed != "" && strings.Contains(host, allowed) {
+					score += 1.0
+					break
+				}
+			}
+		}
+	}
+	return score * weight
+}
+
+// hostOf returns rawURL's host, or "" if it doesn't parse.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
@@ -0,0 +1,214 @@
+package visuals
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/types"
+)
+
+// buildSceneCaptions writes a standalone ASS caption track for one scene's
+// narration, to be burned directly into that scene's own clip/Ken Burns pan
+// in prepareVideoClip/prepareImageWithKenBurns. It uses scene.WordTimings
+// when the TTS provider (or a prior Whisper pass) supplied per-word
+// alignment, falling back to evenly distributing the narration's words
+// across AudioDurationSec otherwise. Returns "" if captions are disabled or
+// the scene has no narration to caption.
+func buildSceneCaptions(cfg *config.Config, scene *types.ScriptScene, outputDir string) (string, error) {
+	if !cfg.Visuals.Subtitles.Enabled {
+		return "", nil
+	}
+
+	words := scene.WordTimings
+	if len(words) == 0 {
+		words = evenlyTimedWords(scene.Narration, scene.AudioDurationSec)
+	}
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	maxChars := cfg.Visuals.Subtitles.MaxCharsPerLine
+	if maxChars <= 0 {
+		maxChars = 28
+	}
+	lineSize := maxChars
+	if cfg.Visuals.Subtitles.Style == "word" {
+		lineSize = 1
+	}
+
+	var dialogue []string
+	for _, chunk := range groupCaptionWords(words, lineSize) {
+		text := make([]string, len(chunk))
+		for i, w := range chunk {
+			text[i] = escapeSceneASSText(w.Word)
+		}
+		dialogue = append(dialogue, fmt.Sprintf("Dialogue: 0,%s,%s,Default,,0,0,0,,%s",
+			formatSceneASSTimestamp(chunk[0].Start), formatSceneASSTimestamp(chunk[len(chunk)-1].End),
+			strings.Join(text, " ")))
+	}
+	if len(dialogue) == 0 {
+		return "", nil
+	}
+
+	outFile := filepath.Join(outputDir, fmt.Sprintf("captions_%03d.ass", scene.Index))
+	content := sceneASSHeader(cfg) + strings.Join(dialogue, "\n") + "\n"
+	if err := os.WriteFile(outFile, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("write scene captions: %w", err)
+	}
+	return outFile, nil
+}
+
+// evenlyTimedWords splits narration on whitespace and spreads the words
+// evenly across duration, for scenes whose TTS provider didn't return
+// per-word alignment.
+func evenlyTimedWords(narration string, duration float64) []types.WordTiming {
+	fields := strings.Fields(narration)
+	if len(fields) == 0 || duration <= 0 {
+		return nil
+	}
+	per := duration / float64(len(fields))
+	words := make([]types.WordTiming, len(fields))
+	for i, w := range fields {
+		words[i] = types.WordTiming{Word: w, Start: float64(i) * per, End: float64(i+1) * per}
+	}
+	return words
+}
+
+// groupCaptionWords groups consecutive words into lines of at most
+// maxWordsOrChars words, breaking early once a line would exceed that many
+// characters. maxWordsOrChars == 1 yields one word per line ("word" style).
+func groupCaptionWords(words []types.WordTiming, maxWordsOrChars int) [][]types.WordTiming {
+	var lines [][]types.WordTiming
+	var cur []types.WordTiming
+	curLen := 0
+
+	for _, w := range words {
+		text := strings.TrimSpace(w.Word)
+		if text == "" {
+			continue
+		}
+		if maxWordsOrChars == 1 {
+			lines = append(lines, []types.WordTiming{w})
+			continue
+		}
+		added := len(text) + 1 // +1 for the joining space
+		if len(cur) > 0 && curLen+added > maxWordsOrChars {
+			lines = append(lines, cur)
+			cur = nil
+			curLen = 0
+		}
+		cur = append(cur, w)
+		curLen += added
+	}
+	if len(cur) > 0 {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+// escapeSceneASSText neutralizes ASS override-block delimiters so
+// narration containing literal braces can't inject unintended style tags.
+func escapeSceneASSText(s string) string {
+	s = strings.ReplaceAll(s, "{", "｛")
+	s = strings.ReplaceAll(s, "}", "｝")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
+
+// formatSceneASSTimestamp renders seconds as ASS's H:MM:SS.cc timestamp format.
+func formatSceneASSTimestamp(sec float64) string {
+	if sec < 0 {
+		sec = 0
+	}
+	totalCentis := int(math.Round(sec * 100))
+	h := totalCentis / 360000
+	m := (totalCentis / 6000) % 60
+	s := (totalCentis / 100) % 60
+	cs := totalCentis % 100
+	return fmt.Sprintf("%d:%02d:%02d.%02d", h, m, s, cs)
+}
+
+// sceneASSHeader builds the [Script Info]/[V4+ Styles]/[Events] preamble.
+// PlayRes matches the 1920x1080 frame every other Assembler filter targets;
+// the bottom-third safe area comes from MarginV, not from PlayRes aspect.
+// Styling here is a fallback only — the scene burn always overrides it with
+// force_style (see subtitleCaptionFilter), same as a plain .srt burn in
+// 05_subtitles.BurnIntoVideo.
+func sceneASSHeader(cfg *config.Config) string {
+	sc := cfg.Visuals.Subtitles
+	fontSize := sc.FontSize
+	if fontSize <= 0 {
+		fontSize = 64
+	}
+	color := sc.Color
+	if color == "" {
+		color = "&H00FFFFFF&" // white fallback
+	}
+	marginV := sc.MarginBottom
+	if marginV <= 0 {
+		marginV = 360 // bottom-third safe area on a 1080-tall frame
+	}
+
+	return fmt.Sprintf(`[Script Info]
+ScriptType: v4.00+
+PlayResX: 1920
+PlayResY: 1080
+WrapStyle: 2
+ScaledBorderAndShadow: yes
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,%s,%d,%s,&H000000FF&,&H00000000&,&H80000000&,-1,0,0,0,100,100,0,0,3,%.0f,0,2,10,10,%d,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`,
+		fontName(sc.Font), fontSize, color, sc.StrokeWidth, marginV,
+	)
+}
+
+func fontName(name string) string {
+	if name == "" {
+		return "Arial"
+	}
+	return name
+}
+
+// subtitleCaptionFilter returns the ffmpeg `subtitles=` filter chain for
+// assFile, styled via force_style from cfg.Visuals.Subtitles, or "" if
+// assFile is empty (captions disabled or nothing to caption).
+func subtitleCaptionFilter(cfg *config.Config, assFile string) string {
+	if assFile == "" {
+		return ""
+	}
+	sc := cfg.Visuals.Subtitles
+	fontSize := sc.FontSize
+	if fontSize <= 0 {
+		fontSize = 64
+	}
+	color := sc.Color
+	if color == "" {
+		color = "&H00FFFFFF&"
+	}
+	marginV := sc.MarginBottom
+	if marginV <= 0 {
+		marginV = 360
+	}
+
+	return fmt.Sprintf(
+		",subtitles=filename='%s':force_style='FontName=%s,FontSize=%d,Bold=-1,PrimaryColour=%s,OutlineColour=&H00000000&,BackColour=&H80000000&,BorderStyle=3,Outline=%.0f,Alignment=2,MarginV=%d'",
+		escapeSceneSubtitlePath(assFile), fontName(sc.Font), fontSize, color, sc.StrokeWidth, marginV,
+	)
+}
+
+// escapeSceneSubtitlePath escapes a path the same way ffmpeg's subtitles=
+// filter requires everywhere else in this pipeline (05_subtitles.BurnIntoVideo).
+func escapeSceneSubtitlePath(path string) string {
+	path = strings.ReplaceAll(path, "\\", "/")
+	path = strings.ReplaceAll(path, ":", "\\:")
+	return path
+}
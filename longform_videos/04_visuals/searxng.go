@@ -0,0 +1,299 @@
+package visuals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/useragent"
+)
+
+// searxngInstanceListURL is searx.space's published directory of public
+// SearXNG instances and their health/capability metrics.
+const searxngInstanceListURL = "https://searx.space/data/instances.json"
+
+// searxngInstanceCacheLifetime is how long a validated instance list stays
+// fresh — searx.space's own directory only regenerates a few times a day,
+// so there's no value refreshing more often than this.
+const searxngInstanceCacheLifetime = 1 * time.Hour
+
+// searxngInstanceCache is a mutex-guarded, periodically-refreshed list of
+// public SearXNG instances that pass our image-search suitability filter.
+// A failed refresh keeps using whatever list is already loaded — the
+// static fallback the first time, or the last successful fetch after —
+// the same attempt-then-degrade shape useragent.Pool uses for its own
+// caniuse refresh.
+type searxngInstanceCache struct {
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	instances []string
+	fetchedAt time.Time
+}
+
+func newSearxngInstanceCache(httpClient *http.Client) *searxngInstanceCache {
+	return &searxngInstanceCache{httpClient: httpClient, instances: staticSearxngInstances()}
+}
+
+// instances returns the cache's current validated instance list,
+// refreshing it first if stale. Like useragent.Pool.Pick, the network
+// fetch itself runs without holding the mutex.
+func (c *searxngInstanceCache) list(ctx context.Context) []string {
+	c.mu.Lock()
+	stale := time.Since(c.fetchedAt) > searxngInstanceCacheLifetime
+	c.mu.Unlock()
+
+	if stale {
+		fresh, err := fetchSearxngInstances(ctx, c.httpClient)
+		c.mu.Lock()
+		if err == nil && len(fresh) > 0 {
+			c.instances = fresh
+		}
+		c.fetchedAt = time.Now()
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]string, len(c.instances))
+	copy(out, c.instances)
+	return out
+}
+
+// searxngInstanceEntry is the subset of searx.space's per-instance fields
+// this package's suitability filter needs.
+type searxngInstanceEntry struct {
+	NetworkType string `json:"network_type"`
+	HTTP        struct {
+		StatusCode int `json:"status_code"`
+	} `json:"http"`
+	Timing struct {
+		Search struct {
+			All struct {
+				Value float64 `json:"value"`
+			} `json:"all"`
+		} `json:"search"`
+	} `json:"timing"`
+	Uptime struct {
+		UptimeDay float64 `json:"uptimeDay"`
+	} `json:"uptime"`
+	Categories []string `json:"categories"`
+}
+
+// fetchSearxngInstances fetches and filters searx.space's instance
+// directory down to instances suitable for scripted image search: HTTPS,
+// an "images" category enabled, >90% day uptime, and a sub-2s average
+// search response time.
+func fetchSearxngInstances(ctx context.Context, client *http.Client) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", searxngInstanceListURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("searxng: instance list returned %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Instances map[string]searxngInstanceEntry `json:"instances"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 8*1024*1024)).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for base, entry := range payload.Instances {
+		if !strings.HasPrefix(base, "https://") {
+			continue
+		}
+		// Only "normal" (plain clearnet) instances — we have no SOCKS
+		// proxy configured to reach the tor/i2p-only ones searx.space also
+		// lists, even though their URLs also start with https://.
+		if entry.NetworkType != "normal" {
+			continue
+		}
+		if entry.HTTP.StatusCode != http.StatusOK {
+			continue
+		}
+		if entry.Uptime.UptimeDay < 90 {
+			continue
+		}
+		if entry.Timing.Search.All.Value > 2.0 {
+			continue
+		}
+		hasImages := false
+		for _, cat := range entry.Categories {
+			if cat == "images" {
+				hasImages = true
+				break
+			}
+		}
+		if !hasImages {
+			continue
+		}
+		out = append(out, base)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("searxng: no suitable instances in directory")
+	}
+	return out, nil
+}
+
+// staticSearxngInstances is the embedded fallback list used until the
+// first live searx.space refresh succeeds — a handful of long-running
+// public instances, honestly a guess rather than a live health check, so
+// SEARXNG_BASE_URL or a live refresh are always preferred when available.
+func staticSearxngInstances() []string {
+	return []string{
+		"https://searx.be/",
+		"https://priv.au/",
+		"https://search.sapti.me/",
+	}
+}
+
+// searxngSource queries a public (or self-hosted, via the SEARXNG_BASE_URL
+// env var or SearXNGConfig.BaseURL) SearXNG meta-search instance's image
+// category — a free alternative to serpAPISource that needs no API key,
+// so (like wikipediaSource and duckDuckGoSource) it's registered by
+// default rather than gated behind a credential env var.
+type searxngSource struct {
+	httpClient *http.Client
+	uaPool     *useragent.Pool
+	baseURL    string // non-empty bypasses instances entirely (self-hosted)
+	instances  *searxngInstanceCache
+	rng        *rand.Rand
+	mu         sync.Mutex // guards rng, which is not safe for concurrent use
+}
+
+// newSearxngSource builds a searxngSource. SEARXNG_BASE_URL, when set,
+// takes priority over cfg.BaseURL — the env var is for an operator
+// pinning their own self-hosted instance without editing YAML, mirroring
+// how serpAPISource/bingImageSource/imgurSource take their credentials.
+func newSearxngSource(cfg config.SearXNGConfig, httpClient *http.Client, uaPool *useragent.Pool) *searxngSource {
+	baseURL := cfg.BaseURL
+	if envURL := os.Getenv("SEARXNG_BASE_URL"); envURL != "" {
+		baseURL = envURL
+	}
+	return &searxngSource{
+		httpClient: httpClient,
+		uaPool:     uaPool,
+		baseURL:    baseURL,
+		instances:  newSearxngInstanceCache(httpClient),
+		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *searxngSource) Name() string { return "searxng" }
+
+// Search tries up to 3 candidate instances (the configured BaseURL alone
+// when set, otherwise a random permutation of the validated directory —
+// without repeats, so a bad attempt doesn't burn retry budget re-trying
+// the same dead instance) before giving up, rotating to another instance
+// on a 4xx/5xx response or an empty result set. All attempts share ctx, so
+// (same as every other ProofSource — ProofScraper.searchSources already
+// bounds each source's whole Search call with one context.WithTimeout) a
+// slow first instance can leave little or no time for the rest; that's an
+// accepted tradeoff of the existing per-source timeout budget, not
+// something this method adds.
+func (s *searxngSource) Search(ctx context.Context, query string, k int) ([]Candidate, error) {
+	var bases []string
+	if s.baseURL != "" {
+		bases = []string{s.baseURL}
+	} else {
+		bases = s.shuffledInstances(ctx)
+		if len(bases) == 0 {
+			return nil, fmt.Errorf("searxng: no instances available")
+		}
+	}
+
+	maxAttempts := 3
+	if len(bases) < maxAttempts {
+		maxAttempts = len(bases)
+	}
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		base := bases[attempt]
+		candidates, err := s.searchInstance(ctx, base, query, k)
+		if err == nil && len(candidates) > 0 {
+			return candidates, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("searxng: %s returned no image results for %q", base, query)
+		}
+		lastErr = err
+		if s.baseURL != "" {
+			break // a configured self-hosted instance has nowhere else to rotate to
+		}
+	}
+	return nil, fmt.Errorf("searxng: all attempts failed: %w", lastErr)
+}
+
+func (s *searxngSource) searchInstance(ctx context.Context, base, query string, k int) ([]Candidate, error) {
+	searchURL := strings.TrimRight(base, "/") + "/search?q=" + url.QueryEscape(query) + "&categories=images&format=json"
+	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.uaPool.Pick(ctx).Apply(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("searxng: %s returned %d", base, resp.StatusCode)
+	}
+
+	var result struct {
+		Results []struct {
+			ImgSrc   string `json:"img_src"`
+			URL      string `json:"url"`
+			Width    int    `json:"width"`
+			Height   int    `json:"height"`
+			Template string `json:"template"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 8*1024*1024)).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Candidate, 0, min(k, len(result.Results)))
+	for _, r := range result.Results {
+		if len(candidates) >= k {
+			break
+		}
+		if r.ImgSrc == "" {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			URL: r.ImgSrc, Width: r.Width, Height: r.Height, PageURL: r.URL, SourceName: s.Name(),
+		})
+	}
+	return candidates, nil
+}
+
+// shuffledInstances returns the validated instance directory in a random
+// order, guarded by s.mu since Search may run concurrently with every
+// other registered ProofSource's Search call and rand.Rand isn't safe for
+// concurrent use.
+func (s *searxngSource) shuffledInstances(ctx context.Context) []string {
+	bases := s.instances.list(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rng.Shuffle(len(bases), func(i, j int) { bases[i], bases[j] = bases[j], bases[i] })
+	return bases
+}
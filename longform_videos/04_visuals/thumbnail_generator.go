@@ -0,0 +1,250 @@
+package visuals
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/freetype"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+
+	"true-crime-pipeline/config"
+)
+
+// thumbnailStyleModifiers are style variations cycled across candidates so
+// each one looks meaningfully different for an A/B comparison, rather than
+// 5 near-identical renders of the same prompt+seed.
+var thumbnailStyleModifiers = []string{
+	"bold red serif title area, dramatic lighting",
+	"shocked face silhouette, high contrast",
+	"yellow arrow overlay pointing at evidence, urgent mood",
+	"dark vignette, cinematic close-up, intense atmosphere",
+	"crime scene tape in frame, moody blue lighting",
+}
+
+// ThumbnailGenerator creates A/B thumbnail candidates for a video: several
+// Pollinations stills varying seed and style modifier off the same base
+// ThumbnailPrompt, each with the video title composited on top, so
+// upload.Uploader can attach one via Thumbnails.Set and a later analytics
+// pass can rotate in the others to correlate CTR with variant.
+type ThumbnailGenerator struct {
+	cfg          *config.Config
+	pollinations *PollinationsFetcher
+	font         *truetype.Font
+}
+
+// NewThumbnailGenerator creates a ThumbnailGenerator, loading
+// Visuals.Thumbnail.FontPath once up front.
+func NewThumbnailGenerator(cfg *config.Config, pollinations *PollinationsFetcher) (*ThumbnailGenerator, error) {
+	fontPath := cfg.Visuals.Thumbnail.FontPath
+	if fontPath == "" {
+		fontPath = "/usr/share/fonts/truetype/dejavu/DejaVuSans-Bold.ttf"
+	}
+	data, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("read thumbnail font %s: %w", fontPath, err)
+	}
+	f, err := freetype.ParseFont(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse thumbnail font %s: %w", fontPath, err)
+	}
+	return &ThumbnailGenerator{cfg: cfg, pollinations: pollinations, font: f}, nil
+}
+
+// ThumbnailCandidate is one successfully generated A/B thumbnail, keeping
+// its original 0-based style/seed index so Thumbnail.WinnerIndex still
+// names the same style even when an earlier candidate failed and would
+// otherwise shift a compacted slice's positions.
+type ThumbnailCandidate struct {
+	Index int
+	Path  string
+}
+
+// Candidates generates Visuals.Thumbnail.Candidates (default 4) 1280x720
+// thumbnails, each a Pollinations still seeded off prompt plus a different
+// style modifier, with a short hook composited on top — titles[i % len(titles)]
+// so a single title (the common case) is reused across every candidate,
+// while metadata.Generator's multiple TitleVariants let each candidate
+// pitch its own angle — and returns the successful candidates in index
+// order. A candidate whose background fetch or text composite fails is
+// skipped rather than failing the whole call; an error is only returned if
+// every candidate failed.
+func (t *ThumbnailGenerator) Candidates(ctx context.Context, prompt string, titles []string, outputDir string) ([]ThumbnailCandidate, error) {
+	n := t.cfg.Visuals.Thumbnail.Candidates
+	if n <= 0 {
+		n = 4
+	}
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("thumbnail: no titles provided")
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, err
+	}
+
+	// Each candidate is an independent fetch+composite against the same
+	// flaky endpoint, so run them concurrently rather than paying the sum
+	// of their retry delays serially — the already-uploaded video is
+	// waiting on this stage to finish.
+	results := make([]string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			style := thumbnailStyleModifiers[i%len(thumbnailStyleModifiers)]
+			candidatePrompt := fmt.Sprintf("%s, %s, 4K photorealistic, no text, no watermark", prompt, style)
+			seed := i*97 + 11 // deterministic per candidate index, for reproducibility across reruns
+			hook := extractHook(titles[i%len(titles)], 4)
+
+			bgFile := filepath.Join(outputDir, fmt.Sprintf("bg_%d.jpg", i))
+			if err := t.fetchBackground(ctx, candidatePrompt, seed, bgFile); err != nil {
+				log.Printf("[thumbnail] Candidate %d background fetch failed: %v — skipping", i, err)
+				return
+			}
+
+			outFile := filepath.Join(outputDir, fmt.Sprintf("thumb_%d.jpg", i))
+			if err := t.compositeTitle(bgFile, hook, outFile); err != nil {
+				log.Printf("[thumbnail] Candidate %d title composite failed: %v — skipping", i, err)
+				return
+			}
+			results[i] = outFile
+		}()
+	}
+	wg.Wait()
+
+	var candidates []ThumbnailCandidate
+	for i, p := range results {
+		if p != "" {
+			candidates = append(candidates, ThumbnailCandidate{Index: i, Path: p})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("all %d thumbnail candidates failed", n)
+	}
+	log.Printf("[thumbnail] ✅ %d/%d candidates ready", len(candidates), n)
+	return candidates, nil
+}
+
+// fetchBackground downloads one 1280x720 Pollinations still, reusing
+// PollinationsFetcher's own HTTP client/cache/validation plumbing rather
+// than opening a second one — thumbnails just need a plain prompt+seed
+// fetch instead of Fetch's scene-oriented signature. Retries up to 3 times
+// like Fetch does, since Pollinations occasionally times out.
+func (t *ThumbnailGenerator) fetchBackground(ctx context.Context, prompt string, seed int, outFile string) error {
+	encoded := url.PathEscape(prompt)
+	imageURL := fmt.Sprintf(
+		"https://image.pollinations.ai/prompt/%s?width=1280&height=720&nologo=true&model=flux&seed=%d",
+		encoded, seed,
+	)
+	var err error
+	for attempt := 1; attempt <= 3; attempt++ {
+		err = t.pollinations.downloadImage(ctx, imageURL, outFile)
+		if err == nil {
+			return nil
+		}
+		time.Sleep(time.Duration(attempt) * 3 * time.Second)
+	}
+	return fmt.Errorf("pollinations fetch failed after 3 attempts: %w", err)
+}
+
+// compositeTitle draws title, word-wrapped and stroked, onto bgFile's image
+// via freetype and writes the result to outFile.
+func (t *ThumbnailGenerator) compositeTitle(bgFile, title, outFile string) error {
+	bgData, err := os.ReadFile(bgFile)
+	if err != nil {
+		return err
+	}
+	img, err := jpeg.Decode(bytes.NewReader(bgData))
+	if err != nil {
+		return fmt.Errorf("decode background: %w", err)
+	}
+
+	canvas := image.NewRGBA(img.Bounds())
+	draw.Draw(canvas, canvas.Bounds(), img, image.Point{}, draw.Src)
+
+	tc := t.cfg.Visuals.Thumbnail
+	fontSize := tc.FontSize
+	if fontSize <= 0 {
+		fontSize = 72
+	}
+	strokeWidth := tc.StrokeWidthPx
+	if strokeWidth <= 0 {
+		strokeWidth = 3
+	}
+	textColor := parseHexColor(tc.TextColor, color.White)
+	strokeColor := parseHexColor(tc.StrokeColor, color.Black)
+
+	lines := wrapLines(title, 20)
+	lineHeight := int(float64(fontSize) * 1.2)
+	y := canvas.Bounds().Dy() - 60 - (len(lines)-1)*lineHeight
+
+	fc := freetype.NewContext()
+	fc.SetDPI(72)
+	fc.SetFont(t.font)
+	fc.SetFontSize(float64(fontSize))
+	fc.SetClip(canvas.Bounds())
+	fc.SetDst(canvas)
+	fc.SetHinting(font.HintingFull)
+
+	x := 48
+	offsets := [][2]int{{-strokeWidth, 0}, {strokeWidth, 0}, {0, -strokeWidth}, {0, strokeWidth}}
+	for _, line := range lines {
+		fc.SetSrc(image.NewUniform(strokeColor))
+		for _, d := range offsets {
+			_, _ = fc.DrawString(line, freetype.Pt(x+d[0], y+d[1]))
+		}
+		fc.SetSrc(image.NewUniform(textColor))
+		if _, err := fc.DrawString(line, freetype.Pt(x, y)); err != nil {
+			return fmt.Errorf("draw title: %w", err)
+		}
+		y += lineHeight
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return jpeg.Encode(f, canvas, &jpeg.Options{Quality: 90})
+}
+
+// extractHook shortens title to its first maxWords words, stripping a
+// trailing punctuation mark, so the thumbnail overlay reads as a punchy
+// hook rather than the full click-bait title wrapped across the image.
+func extractHook(title string, maxWords int) string {
+	words := strings.Fields(title)
+	if len(words) > maxWords {
+		words = words[:maxWords]
+	}
+	hook := strings.Join(words, " ")
+	return strings.TrimRight(hook, ".,;:")
+}
+
+// parseHexColor parses a "#RRGGBB" string into a color.RGBA, returning def
+// unchanged for an empty or malformed value.
+func parseHexColor(hex string, def color.Color) color.Color {
+	if len(hex) != 7 || hex[0] != '#' {
+		return def
+	}
+	r, err1 := strconv.ParseUint(hex[1:3], 16, 8)
+	g, err2 := strconv.ParseUint(hex[3:5], 16, 8)
+	b, err3 := strconv.ParseUint(hex[5:7], 16, 8)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return def
+	}
+	return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}
+}
@@ -0,0 +1,140 @@
+package visuals
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"true-crime-pipeline/04_visuals/videogen"
+	"true-crime-pipeline/04_visuals/videogen/runway"
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/types"
+)
+
+// VideoGenerator produces a short MP4 clip for a "dramatic" scene via a
+// Runway-style text-to-video/image-to-video backend, instead of the
+// PollinationsFetcher still + Ken Burns pan Run uses by default. It gates
+// concurrent jobs at Visuals.VideoGen.MaxConcurrent and caches finished
+// clips on disk keyed on prompt+seed+duration, so re-running the same
+// story doesn't re-bill the provider.
+type VideoGenerator struct {
+	cfg          *config.Config
+	backend      videogen.Generator
+	pollinations *PollinationsFetcher
+	cacheDir     string
+	sem          chan struct{}
+}
+
+// NewVideoGenerator builds a VideoGenerator from cfg. It returns a nil
+// *VideoGenerator (and a nil error) when Visuals.DramaticMode is "image" or
+// unset, or when DramaticMode is "auto" and VideoGen's API key env isn't
+// set — callers treat a nil *VideoGenerator as "use Pollinations stills for
+// every dramatic scene". DramaticMode == "video" with no API key set is a
+// misconfiguration and returns an error instead of silently degrading.
+func NewVideoGenerator(cfg *config.Config, pollinations *PollinationsFetcher) (*VideoGenerator, error) {
+	mode := cfg.Visuals.DramaticMode
+	if mode == "" || mode == "image" {
+		return nil, nil
+	}
+
+	apiKey := os.Getenv(cfg.Visuals.VideoGen.APIKeyEnv)
+	if apiKey == "" {
+		if mode == "video" {
+			return nil, fmt.Errorf("video_gen: dramatic_mode is %q but env var %q is not set", mode, cfg.Visuals.VideoGen.APIKeyEnv)
+		}
+		log.Printf("[visuals] dramatic_mode is \"auto\" but env var %q is not set — staying on Pollinations stills", cfg.Visuals.VideoGen.APIKeyEnv)
+		return nil, nil
+	}
+
+	backend := runway.New(apiKey, cfg.Visuals.VideoGen.Model)
+	if cfg.Visuals.VideoGen.PollIntervalSec > 0 {
+		backend.PollInterval = time.Duration(cfg.Visuals.VideoGen.PollIntervalSec) * time.Second
+	}
+	if cfg.Visuals.VideoGen.MaxPollAttempts > 0 {
+		backend.MaxPollAttempts = cfg.Visuals.VideoGen.MaxPollAttempts
+	}
+
+	maxConcurrent := cfg.Visuals.VideoGen.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	return &VideoGenerator{
+		cfg:          cfg,
+		backend:      backend,
+		pollinations: pollinations,
+		cacheDir:     filepath.Join(cfg.Cache.Path, "videogen"),
+		sem:          make(chan struct{}, maxConcurrent),
+	}, nil
+}
+
+// Fetch returns a short MP4 clip for scene, generating one through the
+// configured backend (seeding it with a Pollinations keyframe first when
+// VideoGen.Mode == "image") or reusing a cached clip from an identical
+// prior request.
+func (v *VideoGenerator) Fetch(ctx context.Context, scene *types.ScriptScene, outputDir string) (string, error) {
+	v.sem <- struct{}{}
+	defer func() { <-v.sem }()
+
+	enhancedPrompt := enhancePrompt(scene.ImagePrompt, scene.Mood)
+	seed := scene.Index*42 + 7
+	duration := scene.AudioDurationSec
+	if duration <= 0 {
+		duration = 5.0
+	}
+
+	cacheFile := filepath.Join(v.cacheDir, videoCacheKey(enhancedPrompt, seed, duration)+".mp4")
+	if info, err := os.Stat(cacheFile); err == nil && info.Size() > 0 {
+		log.Printf("[visuals] Scene %d: reusing cached video-gen clip", scene.Index)
+		return cacheFile, nil
+	}
+
+	req := videogen.Request{Prompt: enhancedPrompt, DurationSec: duration, Seed: seed}
+	if v.cfg.Visuals.VideoGen.Mode == "image" {
+		keyframe, err := v.pollinations.Fetch(ctx, scene, outputDir)
+		if err != nil {
+			return "", fmt.Errorf("video_gen: keyframe fetch: %w", err)
+		}
+		req.Keyframe = keyframe
+	}
+
+	if err := os.MkdirAll(v.cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("video_gen: create cache dir: %w", err)
+	}
+
+	tmpFile := cacheFile + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return "", err
+	}
+	genErr := v.backend.Generate(ctx, req, f)
+	closeErr := f.Close()
+	if genErr != nil {
+		os.Remove(tmpFile)
+		return "", fmt.Errorf("video_gen: generate scene %d: %w", scene.Index, genErr)
+	}
+	if closeErr != nil {
+		os.Remove(tmpFile)
+		return "", closeErr
+	}
+	if err := os.Rename(tmpFile, cacheFile); err != nil {
+		return "", err
+	}
+
+	log.Printf("[visuals] ✅ Scene %d video-gen clip saved: %s", scene.Index, cacheFile)
+	return cacheFile, nil
+}
+
+// videoCacheKey hashes prompt+seed+duration into the cache entry's
+// filename stem, so an identical scene request across runs reuses the same
+// clip instead of re-billing the provider.
+func videoCacheKey(prompt string, seed int, duration float64) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%d\n%.3f", prompt, seed, duration)
+	return hex.EncodeToString(h.Sum(nil))
+}
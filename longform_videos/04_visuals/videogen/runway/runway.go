@@ -0,0 +1,229 @@
+// Package runway speaks the Runway ML Gen-3-style text-to-video and
+// image-to-video API directly: submit a job, poll its task id with a
+// bounded backoff until it succeeds or fails, then download the resulting
+// asset.
+package runway
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"true-crime-pipeline/04_visuals/videogen"
+)
+
+const (
+	baseURL    = "https://api.runwayml.com/v1"
+	apiVersion = "2024-11-06"
+)
+
+// Provider generates video through the Runway ML API.
+type Provider struct {
+	APIKey     string
+	Model      string
+	HTTPClient *http.Client
+	// PollInterval is the base delay between job-status polls; 0 means 5s.
+	PollInterval time.Duration
+	// MaxPollAttempts bounds how long Generate waits for one job; 0 means
+	// 60 (~5 minutes at the default interval).
+	MaxPollAttempts int
+}
+
+// New returns a Provider authenticated with apiKey, generating with model
+// (e.g. "gen3a_turbo"); an empty model defaults to that at Generate time.
+func New(apiKey, model string) *Provider {
+	return &Provider{
+		APIKey:     apiKey,
+		Model:      model,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type submitRequest struct {
+	PromptText  string `json:"promptText"`
+	PromptImage string `json:"promptImage,omitempty"` // data URI, image-to-video only
+	Model       string `json:"model"`
+	Duration    int    `json:"duration"`
+	Seed        int    `json:"seed,omitempty"`
+}
+
+type taskResponse struct {
+	ID      string   `json:"id"`
+	Status  string   `json:"status"`
+	Output  []string `json:"output"`
+	Failure string   `json:"failure"`
+}
+
+func (p *Provider) Generate(ctx context.Context, req videogen.Request, out io.Writer) error {
+	model := p.Model
+	if model == "" {
+		model = "gen3a_turbo"
+	}
+
+	body := submitRequest{
+		PromptText: req.Prompt,
+		Model:      model,
+		Duration:   roundToSupportedDuration(req.DurationSec),
+		Seed:       req.Seed,
+	}
+
+	endpoint := baseURL + "/text_to_video"
+	if req.Keyframe != "" {
+		dataURI, err := encodeImageDataURI(req.Keyframe)
+		if err != nil {
+			return fmt.Errorf("runway: encode keyframe: %w", err)
+		}
+		body.PromptImage = dataURI
+		endpoint = baseURL + "/image_to_video"
+	}
+
+	taskID, err := p.submit(ctx, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	assetURL, err := p.pollUntilDone(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	return p.download(ctx, assetURL, out)
+}
+
+func (p *Provider) submit(ctx context.Context, endpoint string, body submitRequest) (string, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	p.authHeaders(httpReq)
+
+	resp, err := p.HTTPClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("runway: submit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("runway: submit status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var task taskResponse
+	if err := json.Unmarshal(respBody, &task); err != nil {
+		return "", fmt.Errorf("runway: parse submit response: %w", err)
+	}
+	if task.ID == "" {
+		return "", fmt.Errorf("runway: submit response had no task id")
+	}
+	return task.ID, nil
+}
+
+// pollUntilDone polls the task until it succeeds, fails, or
+// MaxPollAttempts is exhausted, sleeping PollInterval*min(attempt,6)
+// between checks.
+func (p *Provider) pollUntilDone(ctx context.Context, taskID string) (string, error) {
+	interval := p.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	maxAttempts := p.MaxPollAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 60
+	}
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/tasks/%s", baseURL, taskID), nil)
+		if err != nil {
+			return "", err
+		}
+		p.authHeaders(httpReq)
+
+		resp, err := p.HTTPClient.Do(httpReq)
+		if err != nil {
+			return "", fmt.Errorf("runway: poll: %w", err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return "", err
+		}
+
+		var task taskResponse
+		if err := json.Unmarshal(respBody, &task); err != nil {
+			return "", fmt.Errorf("runway: parse poll response: %w", err)
+		}
+
+		switch task.Status {
+		case "SUCCEEDED":
+			if len(task.Output) == 0 {
+				return "", fmt.Errorf("runway: task %s succeeded with no output", taskID)
+			}
+			return task.Output[0], nil
+		case "FAILED":
+			return "", fmt.Errorf("runway: task %s failed: %s", taskID, task.Failure)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval * time.Duration(min(attempt, 6))):
+		}
+	}
+	return "", fmt.Errorf("runway: task %s did not finish after %d polls", taskID, maxAttempts)
+}
+
+func (p *Provider) download(ctx context.Context, assetURL string, out io.Writer) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, assetURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("runway: download asset: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("runway: download status %d", resp.StatusCode)
+	}
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+func (p *Provider) authHeaders(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+p.APIKey)
+	req.Header.Set("X-Runway-Version", apiVersion)
+	req.Header.Set("Content-Type", "application/json")
+}
+
+func encodeImageDataURI(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// roundToSupportedDuration rounds up to Runway's fixed clip lengths (5 or
+// 10 seconds); Assembler.prepareVideoClip trims the result down to the
+// scene's exact narration length afterward, same as it does for any other
+// source clip.
+func roundToSupportedDuration(sec float64) int {
+	if sec > 5 {
+		return 10
+	}
+	return 5
+}
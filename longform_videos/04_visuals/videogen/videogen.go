@@ -0,0 +1,30 @@
+// Package videogen defines the pluggable text-to-video backend
+// visuals.VideoGenerator drives for "dramatic" scenes: a prompt (and, in
+// image-to-video mode, a keyframe image) goes in, a short MP4 clip goes
+// out. Implementations (videogen/runway) speak a vendor's async
+// submit-job/poll-status/download-asset API.
+package videogen
+
+import (
+	"context"
+	"io"
+)
+
+// Request describes one video-generation job.
+type Request struct {
+	// Prompt is the scene's (mood-enhanced) image/video prompt.
+	Prompt string
+	// DurationSec is how long the returned clip should be.
+	DurationSec float64
+	// Seed makes repeat requests for the same scene deterministic.
+	Seed int
+	// Keyframe, if set, is the path to a still image to animate
+	// (image-to-video). Empty means pure text-to-video.
+	Keyframe string
+}
+
+// Generator submits req to a vendor's text-to-video/image-to-video API and
+// writes the resulting MP4 bytes to out once the async job completes.
+type Generator interface {
+	Generate(ctx context.Context, req Request, out io.Writer) error
+}
@@ -0,0 +1,589 @@
+package visuals
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"true-crime-pipeline/assetcache"
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/media"
+	"true-crime-pipeline/media/validate"
+	"true-crime-pipeline/types"
+)
+
+// Assembler coordinates all visual preparation for the pipeline
+type Assembler struct {
+	cfg          *config.Config
+	assetManager *AssetManager
+	pollinations *PollinationsFetcher
+	proofScraper *ProofScraper
+	// videoGen is non-nil when Visuals.DramaticMode enables video
+	// generation for "dramatic" scenes; nil means Pollinations-stills-only.
+	videoGen *VideoGenerator
+}
+
+// NewAssembler creates a new visual Assembler
+func NewAssembler(cfg *config.Config, runID string) (*Assembler, error) {
+	am, err := NewAssetManager(cfg, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	pollinations := NewPollinationsFetcher(cfg)
+	videoGen, err := NewVideoGenerator(cfg, pollinations)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Assembler{
+		cfg:          cfg,
+		assetManager: am,
+		pollinations: pollinations,
+		proofScraper: NewProofScraper(cfg),
+		videoGen:     videoGen,
+	}, nil
+}
+
+// Close releases the Assembler's long-lived resources — currently the
+// ProofScraper's headless Chrome instance. Call it once on pipeline exit.
+func (a *Assembler) Close() {
+	a.proofScraper.Close()
+}
+
+// Run prepares all visual files for every scene
+func (a *Assembler) Run(ctx context.Context, script *types.Script, story *types.Story, outputDir string) error {
+	log.Println("[visuals] Preparing visual assets for all scenes...")
+
+	visualDir := filepath.Join(outputDir, "visuals")
+	if err := os.MkdirAll(visualDir, 0755); err != nil {
+		return err
+	}
+
+	prober := media.New(outputDir)
+
+	for i := range script.Scenes {
+		scene := &script.Scenes[i]
+		log.Printf("[visuals] Scene %d/%d (%s, mood: %s)", i+1, len(script.Scenes), scene.SceneType, scene.Mood)
+
+		switch scene.SceneType {
+		case "cinematic":
+			clip, pickErr := a.assetManager.Pick(scene)
+			if pickErr != nil {
+				log.Printf("[visuals] Warning scene %d: %v — using dramatic fallback", i, pickErr)
+			} else if prepared, prepErr := a.prepareVideoClip(ctx, clip, scene, visualDir); prepErr != nil {
+				log.Printf("[visuals] Warning scene %d: clip prep failed: %v — using dramatic fallback", i, prepErr)
+			} else if valErr := validate.VideoClip(ctx, prober, prepared, scene.AudioDurationSec); valErr != nil {
+				log.Printf("[visuals] Warning scene %d: picked clip failed validation: %v — using dramatic fallback", i, valErr)
+			} else {
+				scene.VisualFile = prepared
+				break
+			}
+			// Fall through to dramatic
+			scene.SceneType = "dramatic"
+			if scene.ImagePrompt == "" {
+				scene.ImagePrompt = fmt.Sprintf("dark crime scene %s atmosphere cinematic", scene.Mood)
+			}
+			img, err := a.fetchValidatedStill(ctx, prober, scene, visualDir)
+			if err != nil {
+				log.Printf("[visuals] Warning scene %d: visual fallback failed validation: %v — using degraded card", i, err)
+				img = a.createDegradedCard(visualDir, scene)
+			}
+			prepared, err := a.prepareStillMotion(ctx, img, scene, visualDir)
+			if err != nil {
+				return err
+			}
+			scene.VisualFile = prepared
+
+		case "dramatic":
+			var prepared string
+			if a.videoGen != nil {
+				clip, err := a.videoGen.Fetch(ctx, scene, visualDir)
+				if err != nil {
+					log.Printf("[visuals] Warning scene %d: video-gen failed: %v — falling back to Pollinations still", i, err)
+				} else if prepared, err = a.prepareVideoClip(ctx, clip, scene, visualDir); err != nil {
+					return fmt.Errorf("scene %d video-gen clip prep failed: %w", i, err)
+				} else if valErr := validate.VideoClip(ctx, prober, prepared, scene.AudioDurationSec); valErr != nil {
+					log.Printf("[visuals] Warning scene %d: video-gen clip failed validation: %v — falling back to Pollinations still", i, valErr)
+					prepared = ""
+				}
+			}
+			if prepared == "" {
+				img, err := a.fetchValidatedStill(ctx, prober, scene, visualDir)
+				if err != nil {
+					log.Printf("[visuals] Warning scene %d: Pollinations failed validation: %v — using degraded card", i, err)
+					img = a.createDegradedCard(visualDir, scene)
+				}
+				prepared, err = a.prepareStillMotion(ctx, img, scene, visualDir)
+				if err != nil {
+					return err
+				}
+			}
+			scene.VisualFile = prepared
+
+		case "proof":
+			img, asset, err := a.proofScraper.FetchProofImage(ctx, scene, story, visualDir)
+			if err == nil && asset != nil {
+				scene.ProofBlurhash = asset.Blurhash
+			}
+			if err == nil {
+				if valErr := validate.Image(ctx, prober, img); valErr != nil {
+					log.Printf("[visuals] Warning scene %d: proof image failed validation: %v", i, valErr)
+					err = valErr
+				}
+			}
+			if err != nil {
+				log.Printf("[visuals] Warning scene %d: no usable proof image: %v", i, err)
+				// Fall back to dramatic for this scene
+				scene.SceneType = "dramatic"
+				if scene.ImagePrompt == "" {
+					scene.ImagePrompt = "evidence document crime scene investigation cinematic"
+				}
+				img2, err2 := a.fetchValidatedStill(ctx, prober, scene, visualDir)
+				if err2 != nil {
+					// A blurhash from the proof fetch that produced bytes
+					// but failed validation is a better placeholder than
+					// createDegradedCard's fixed dark-gray card.
+					img2 = a.createDegradedCard(visualDir, scene)
+				}
+				prepared, err3 := a.prepareStillMotion(ctx, img2, scene, visualDir)
+				if err3 != nil {
+					return err3
+				}
+				scene.VisualFile = prepared
+			} else {
+				// Proof scene: add source credit overlay
+				credited, err := a.addSourceCredit(ctx, img, scene, story, visualDir)
+				if err != nil {
+					credited = img // use without credit if it fails
+				}
+				scene.VisualFile = credited
+			}
+		}
+
+		log.Printf("[visuals] ✅ Scene %d visual ready: %s", i, scene.VisualFile)
+	}
+
+	return nil
+}
+
+// fetchValidatedStill fetches a Pollinations still for scene, retrying up
+// to Validation.MaxRetries times when the result fails validate.Image's
+// size/stream check — catching Pollinations' occasional 200-with-a-tiny-
+// error-PNG response, which Fetch's own <100-byte check lets through.
+// Exhausting retries returns an error so the caller can fall back to a
+// degraded card.
+func (a *Assembler) fetchValidatedStill(ctx context.Context, prober *media.Prober, scene *types.ScriptScene, visualDir string) (string, error) {
+	retries := a.cfg.Validation.MaxRetries
+	if retries <= 0 {
+		retries = 2
+	}
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		img, err := a.pollinations.Fetch(ctx, scene, visualDir)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err := validate.Image(ctx, prober, img); err != nil {
+			lastErr = err
+			log.Printf("[visuals] Scene %d: generated image failed validation (attempt %d/%d): %v", scene.Index, attempt+1, retries+1, err)
+			continue
+		}
+		return img, nil
+	}
+	return "", fmt.Errorf("exhausted %d attempts: %w", retries+1, lastErr)
+}
+
+// prepareVideoClip trims or loops a video clip to match the scene's narration duration
+func (a *Assembler) prepareVideoClip(ctx context.Context, clipPath string, scene *types.ScriptScene, outputDir string) (string, error) {
+	outFile := filepath.Join(outputDir, fmt.Sprintf("clip_%03d.mp4", scene.Index))
+	duration := scene.AudioDurationSec
+	if duration <= 0 {
+		duration = 5.0
+	}
+
+	captions, err := buildSceneCaptions(a.cfg, scene, outputDir)
+	if err != nil {
+		log.Printf("[visuals] Warning scene %d: caption generation failed: %v — burning clip without captions", scene.Index, err)
+	}
+	vf := "scale=1920:1080:force_original_aspect_ratio=decrease,pad=1920:1080:(ow-iw)/2:(oh-ih)/2" + subtitleCaptionFilter(a.cfg, captions)
+
+	// Get clip duration
+	clipDur, err := getVideoDuration(clipPath)
+	if err != nil {
+		clipDur = duration // assume same length if we can't measure
+	}
+
+	var cmd *exec.Cmd
+	if clipDur >= duration {
+		// Trim to exact duration
+		cmd = exec.CommandContext(ctx, "ffmpeg", "-y",
+			"-i", clipPath,
+			"-t", fmt.Sprintf("%.3f", duration),
+			"-vf", vf,
+			"-c:v", "libx264",
+			"-preset", "fast",
+			"-crf", "23",
+			"-an", // no audio from clip
+			outFile,
+		)
+	} else {
+		// Loop the clip to fill the duration
+		loops := int(duration/clipDur) + 2
+		cmd = exec.CommandContext(ctx, "ffmpeg", "-y",
+			"-stream_loop", fmt.Sprintf("%d", loops),
+			"-i", clipPath,
+			"-t", fmt.Sprintf("%.3f", duration),
+			"-vf", vf,
+			"-c:v", "libx264",
+			"-preset", "fast",
+			"-crf", "23",
+			"-an",
+			outFile,
+		)
+	}
+
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ffmpeg clip prep: %w", err)
+	}
+	return outFile, nil
+}
+
+// prepareStillMotion applies the configured Visuals.Motion treatment
+// ("none", "kenburns" — the default, or "parallax") to a still image. This
+// is the single entry point every scene-type branch in Run calls for its
+// still fallback/primary visual, so switching Motion affects proof,
+// dramatic, and fallback scenes alike.
+func (a *Assembler) prepareStillMotion(ctx context.Context, imgPath string, scene *types.ScriptScene, outputDir string) (string, error) {
+	switch a.cfg.Visuals.Motion {
+	case "none":
+		return a.prepareImageStatic(ctx, imgPath, scene, outputDir)
+	case "parallax":
+		prepared, err := a.prepareImageWithParallax(ctx, imgPath, scene, outputDir)
+		if err != nil {
+			log.Printf("[visuals] Warning scene %d: parallax motion failed: %v — falling back to Ken Burns", scene.Index, err)
+			return a.prepareImageWithKenBurns(ctx, imgPath, scene, outputDir)
+		}
+		return prepared, nil
+	case "", "kenburns":
+		return a.prepareImageWithKenBurns(ctx, imgPath, scene, outputDir)
+	default:
+		log.Printf("[visuals] Warning: unrecognized Visuals.Motion %q — defaulting to Ken Burns", a.cfg.Visuals.Motion)
+		return a.prepareImageWithKenBurns(ctx, imgPath, scene, outputDir)
+	}
+}
+
+// kenBurnsZoomAndPan resolves this scene's zoom target and pan direction:
+// zoom scales KenBurnsZoomFactor by MotionIntensity (0 means no scaling,
+// i.e. the full configured zoom), and the pan direction is chosen from the
+// scene's own index rather than wall-clock randomness, so re-running the
+// same script always reproduces the same motion and consecutive scenes
+// don't all drift toward the same corner.
+func (a *Assembler) kenBurnsZoomAndPan(scene *types.ScriptScene) (zoom float64, startX, startY, endX, endY string) {
+	zoom = a.cfg.Visuals.KenBurnsZoomFactor
+	if zoom <= 0 {
+		zoom = 1.08
+	}
+	intensity := a.cfg.Visuals.MotionIntensity
+	if intensity <= 0 {
+		intensity = 1.0
+	}
+	zoom = 1.0 + (zoom-1.0)*intensity
+
+	// Four diagonal pans, corner to corner; "iw-iw/zoom"/"ih-ih/zoom" are the
+	// zoompan x/y expressions for the opposite edge of the frame at the
+	// current per-frame zoom level.
+	directions := [][4]string{
+		{"0", "0", "iw-iw/zoom", "ih-ih/zoom"}, // top-left -> bottom-right
+		{"iw-iw/zoom", "ih-ih/zoom", "0", "0"}, // bottom-right -> top-left
+		{"0", "ih-ih/zoom", "iw-iw/zoom", "0"}, // bottom-left -> top-right
+		{"iw-iw/zoom", "0", "0", "ih-ih/zoom"}, // top-right -> bottom-left
+	}
+	d := directions[scene.Index%len(directions)]
+	return zoom, d[0], d[1], d[2], d[3]
+}
+
+// kenBurnsPanExpr builds a zoompan x/y expression that linearly interpolates
+// between start and end (both zoompan position expressions) over the clip's
+// totalFrames, using zoompan's own "on" (current output frame) variable.
+func kenBurnsPanExpr(start, end string, totalFrames int) string {
+	return fmt.Sprintf("(%s)+(((%s))-((%s)))*on/%d", start, end, start, totalFrames)
+}
+
+// prepareImageWithKenBurns applies a slow Ken Burns zoom-and-pan to a still
+// image, panning diagonally across the frame as it zooms in.
+func (a *Assembler) prepareImageWithKenBurns(ctx context.Context, imgPath string, scene *types.ScriptScene, outputDir string) (string, error) {
+	outFile := filepath.Join(outputDir, fmt.Sprintf("kenburns_%03d.mp4", scene.Index))
+	duration := scene.AudioDurationSec
+	if duration <= 0 {
+		duration = 5.0
+	}
+
+	fps := a.cfg.Visuals.FPS
+	totalFrames := int(duration * float64(fps))
+	zoom, startX, startY, endX, endY := a.kenBurnsZoomAndPan(scene)
+
+	captions, err := buildSceneCaptions(a.cfg, scene, outputDir)
+	if err != nil {
+		log.Printf("[visuals] Warning scene %d: caption generation failed: %v — burning Ken Burns pan without captions", scene.Index, err)
+	}
+
+	// Ken Burns: slow zoom in from 1.0 to zoom factor, panning diagonally
+	// from startX/startY to endX/endY as it does.
+	zoomStep := (zoom - 1.0) / float64(totalFrames)
+	zoomFilter := fmt.Sprintf(
+		"scale=3840:2160,zoompan=z='min(zoom+%.6f,%.3f)':x='%s':y='%s':d=%d:fps=%d,scale=1920:1080%s",
+		zoomStep, zoom, kenBurnsPanExpr(startX, endX, totalFrames), kenBurnsPanExpr(startY, endY, totalFrames), totalFrames, fps, subtitleCaptionFilter(a.cfg, captions),
+	)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y",
+		"-loop", "1",
+		"-i", imgPath,
+		"-vf", zoomFilter,
+		"-t", fmt.Sprintf("%.3f", duration),
+		"-c:v", "libx264",
+		"-preset", "fast",
+		"-crf", "23",
+		"-pix_fmt", "yuv420p",
+		"-an",
+		outFile,
+	)
+
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ffmpeg ken burns: %w", err)
+	}
+	return outFile, nil
+}
+
+// prepareImageStatic scales/pads a still image to fill the frame for its
+// scene's duration with no motion at all — Visuals.Motion: "none".
+func (a *Assembler) prepareImageStatic(ctx context.Context, imgPath string, scene *types.ScriptScene, outputDir string) (string, error) {
+	outFile := filepath.Join(outputDir, fmt.Sprintf("static_%03d.mp4", scene.Index))
+	duration := scene.AudioDurationSec
+	if duration <= 0 {
+		duration = 5.0
+	}
+
+	captions, err := buildSceneCaptions(a.cfg, scene, outputDir)
+	if err != nil {
+		log.Printf("[visuals] Warning scene %d: caption generation failed: %v — burning static frame without captions", scene.Index, err)
+	}
+
+	vf := "scale=1920:1080:force_original_aspect_ratio=decrease,pad=1920:1080:(ow-iw)/2:(oh-ih)/2,setsar=1" + subtitleCaptionFilter(a.cfg, captions)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y",
+		"-loop", "1",
+		"-i", imgPath,
+		"-vf", vf,
+		"-t", fmt.Sprintf("%.3f", duration),
+		"-c:v", "libx264",
+		"-preset", "fast",
+		"-crf", "23",
+		"-pix_fmt", "yuv420p",
+		"-an",
+		outFile,
+	)
+
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ffmpeg static still: %w", err)
+	}
+	return outFile, nil
+}
+
+// prepareImageWithParallax approximates 2.5D depth on a still image without
+// a real depth map: it splits the source into two layers zoomed/panned at
+// different speeds — a slow full-frame background and a faster, more
+// tightly-cropped foreground blended on top at partial opacity — which
+// reads as parallax drift even though both layers come from the same flat
+// image. A true depth-aware split (e.g. a MiDaS pass) would look better but
+// needs an ML inference dependency this pipeline doesn't otherwise carry;
+// this keeps the effect to a single ffmpeg invocation like every other
+// visuals step. Visuals.Motion: "parallax".
+func (a *Assembler) prepareImageWithParallax(ctx context.Context, imgPath string, scene *types.ScriptScene, outputDir string) (string, error) {
+	outFile := filepath.Join(outputDir, fmt.Sprintf("parallax_%03d.mp4", scene.Index))
+	duration := scene.AudioDurationSec
+	if duration <= 0 {
+		duration = 5.0
+	}
+
+	fps := a.cfg.Visuals.FPS
+	totalFrames := int(duration * float64(fps))
+	zoom, startX, startY, endX, endY := a.kenBurnsZoomAndPan(scene)
+
+	// Foreground drifts roughly twice as far and zooms in faster than the
+	// background, selling the illusion of it being closer to the camera.
+	bgZoom := 1.0 + (zoom-1.0)*0.4
+	fgZoom := 1.0 + (zoom-1.0)*1.6
+
+	captions, err := buildSceneCaptions(a.cfg, scene, outputDir)
+	if err != nil {
+		log.Printf("[visuals] Warning scene %d: caption generation failed: %v — burning parallax without captions", scene.Index, err)
+	}
+	captionSuffix := subtitleCaptionFilter(a.cfg, captions)
+
+	bgStep := (bgZoom - 1.0) / float64(totalFrames)
+	fgStep := (fgZoom - 1.0) / float64(totalFrames)
+	filterComplex := fmt.Sprintf(
+		"[0:v]split=2[bg][fg];"+
+			"[bg]scale=3840:2160,zoompan=z='min(zoom+%.6f,%.3f)':x='%s':y='%s':d=%d:fps=%d,scale=1920:1080[bglayer];"+
+			"[fg]scale=3840:2160,zoompan=z='min(zoom+%.6f,%.3f)':x='%s':y='%s':d=%d:fps=%d,scale=1920:1080,format=rgba,colorchannelmixer=aa=0.35[fglayer];"+
+			"[bglayer][fglayer]overlay=format=auto[ov];"+
+			"[ov]null%s[outv]",
+		bgStep, bgZoom, kenBurnsPanExpr(startX, endX, totalFrames), kenBurnsPanExpr(startY, endY, totalFrames), totalFrames, fps,
+		fgStep, fgZoom, kenBurnsPanExpr(startX, endX, totalFrames), kenBurnsPanExpr(startY, endY, totalFrames), totalFrames, fps,
+		captionSuffix,
+	)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y",
+		"-loop", "1",
+		"-i", imgPath,
+		"-filter_complex", filterComplex,
+		"-map", "[outv]",
+		"-t", fmt.Sprintf("%.3f", duration),
+		"-c:v", "libx264",
+		"-preset", "fast",
+		"-crf", "23",
+		"-pix_fmt", "yuv420p",
+		"-an",
+		outFile,
+	)
+
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ffmpeg parallax: %w", err)
+	}
+	return outFile, nil
+}
+
+// addSourceCredit burns a source credit overlay onto the proof image
+func (a *Assembler) addSourceCredit(ctx context.Context, imgPath string, scene *types.ScriptScene, story *types.Story, outputDir string) (string, error) {
+	outFile := filepath.Join(outputDir, fmt.Sprintf("proof_credited_%03d.jpg", scene.Index))
+
+	credit := fmt.Sprintf("Source: %s", story.Source)
+	if story.PublishedAt != "" && len(story.PublishedAt) >= 4 {
+		credit += ", " + story.PublishedAt[:4]
+	}
+
+	// FFmpeg drawtext filter for source credit
+	drawtextFilter := fmt.Sprintf(
+		"scale=960:640,drawtext=text='%s':fontcolor=white:fontsize=18:box=1:boxcolor=black@0.6:boxborderw=5:x=w-tw-10:y=h-th-10",
+		escapeFFmpegText(credit),
+	)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-y",
+		"-i", imgPath,
+		"-vf", drawtextFilter,
+		"-q:v", "2",
+		outFile,
+	)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ffmpeg source credit: %w", err)
+	}
+	return outFile, nil
+}
+
+// createFallbackFrame creates a solid dark frame with no text — the last
+// resort when createDegradedCard's own drawtext pass fails.
+func (a *Assembler) createFallbackFrame(outputDir string, sceneIndex int) string {
+	outFile := filepath.Join(outputDir, fmt.Sprintf("fallback_%03d.jpg", sceneIndex))
+	_ = exec.Command("ffmpeg", "-y",
+		"-f", "lavfi",
+		"-i", "color=c=black:s=1920x1080:d=1",
+		"-frames:v", "1",
+		outFile,
+	).Run()
+	return outFile
+}
+
+// createDegradedCard creates a solid-color placeholder frame with the
+// scene's narration burned in as text, used when every attempt at a real
+// visual (asset match, video-gen, Pollinations) fails validate's checks —
+// so the scene still reads instead of rendering on a wordless black frame.
+// Marks scene.Degraded so state.json surfaces which scenes shipped without
+// a real visual. The background is scene.ProofBlurhash's average color when
+// set (a proof image was fetched but later failed validation) instead of
+// the fixed dark gray, so a failed proof scene's card at least hints at the
+// image's dominant color rather than going flat gray like every other kind
+// of fallback.
+func (a *Assembler) createDegradedCard(outputDir string, scene *types.ScriptScene) string {
+	scene.Degraded = true
+	outFile := filepath.Join(outputDir, fmt.Sprintf("degraded_%03d.jpg", scene.Index))
+
+	bgColor := "0x1a1a1a"
+	if hex, ok := assetcache.BlurhashAverageColorHex(scene.ProofBlurhash); ok {
+		bgColor = hex
+	}
+
+	drawtextFilter := fmt.Sprintf(
+		"drawtext=text='%s':fontcolor=white:fontsize=40:box=1:boxcolor=black@0.5:boxborderw=24:x=(w-text_w)/2:y=(h-text_h)/2:line_spacing=12",
+		escapeFFmpegText(strings.Join(wrapLines(scene.Narration, 36), "\n")),
+	)
+
+	cmd := exec.Command("ffmpeg", "-y",
+		"-f", "lavfi",
+		"-i", fmt.Sprintf("color=c=%s:s=1920x1080:d=1", bgColor),
+		"-vf", drawtextFilter,
+		"-frames:v", "1",
+		outFile,
+	)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Printf("[visuals] Warning scene %d: degraded card drawtext failed: %v — using plain fallback frame", scene.Index, err)
+		return a.createFallbackFrame(outputDir, scene.Index)
+	}
+	return outFile
+}
+
+// wrapLines greedily wraps s onto lines no longer than width characters, on
+// word boundaries — shared by createDegradedCard's drawtext burn and
+// ThumbnailGenerator's title overlay.
+func wrapLines(s string, width int) []string {
+	words := strings.Fields(s)
+	var lines []string
+	var line string
+	for _, w := range words {
+		switch {
+		case line == "":
+			line = w
+		case len(line)+1+len(w) <= width:
+			line += " " + w
+		default:
+			lines = append(lines, line)
+			line = w
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func getVideoDuration(path string) (float64, error) {
+	out, err := exec.Command("ffprobe",
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path,
+	).Output()
+	if err != nil {
+		return 0, err
+	}
+	var dur float64
+	_, err = fmt.Sscanf(strings.TrimSpace(string(out)), "%f", &dur)
+	return dur, err
+}
+
+func escapeFFmpegText(s string) string {
+	s = strings.ReplaceAll(s, "'", "\\'")
+	s = strings.ReplaceAll(s, ":", "\\:")
+	return s
+}
@@ -0,0 +1,241 @@
+// Package webdriver drives a headless Chrome via chromedp to pull proof
+// images off JS-rendered evidence pages — news sites, court-document
+// viewers, archive pages — whose <img> tags don't exist until client-side
+// JavaScript runs, so ProofScraper's plain HTTP GETs never see them.
+package webdriver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	cdpruntime "github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+
+	"true-crime-pipeline/config"
+)
+
+const (
+	defaultTimeout = 20 * time.Second
+	defaultUA      = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36"
+	// minImageEdge is the smallest longest-edge size (in CSS pixels) an
+	// in-viewport <img> needs to be considered a candidate proof image
+	// rather than an icon/avatar/tracking pixel.
+	minImageEdge = 400
+)
+
+// Renderer owns one long-lived headless Chrome instance, started lazily on
+// the first Fetch and reused across every scene afterward instead of
+// paying Chrome's startup cost per proof image. Concurrent Fetch calls
+// share that one browser process but are bounded by a worker-pool
+// semaphore sized to GOMAXPROCS, so a caller fanning many scenes out at
+// once can't spin up more simultaneous tabs than the host has cores to
+// drive. Close shuts it down.
+type Renderer struct {
+	cfg config.HeadlessBrowserConfig
+
+	enabledMu sync.RWMutex
+	enabled   bool
+
+	startOnce     sync.Once
+	allocCancel   context.CancelFunc
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+
+	sem chan struct{}
+}
+
+// New creates a Renderer for cfg. The Chrome process isn't launched until
+// the first Fetch call.
+func New(cfg config.HeadlessBrowserConfig) *Renderer {
+	workers := runtime.GOMAXPROCS(0)
+	if workers < 1 {
+		workers = 1
+	}
+	return &Renderer{cfg: cfg, enabled: cfg.Enabled, sem: make(chan struct{}, workers)}
+}
+
+// SetEnabled overrides cfg.Enabled at runtime — the same escape hatch
+// ProofScraper.EnableHeadless exposes to its own callers, threaded down so
+// turning headless rendering on for a pipeline whose static config has it
+// disabled actually takes effect here too.
+func (r *Renderer) SetEnabled(enabled bool) {
+	r.enabledMu.Lock()
+	defer r.enabledMu.Unlock()
+	r.enabled = enabled
+}
+
+func (r *Renderer) start() {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.UserAgent(r.userAgent()),
+	)
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+	r.allocCancel = allocCancel
+	r.browserCtx = browserCtx
+	r.browserCancel = browserCancel
+}
+
+func (r *Renderer) userAgent() string {
+	if r.cfg.UserAgent != "" {
+		return r.cfg.UserAgent
+	}
+	return defaultUA
+}
+
+// Close shuts down the shared headless Chrome instance. Safe to call even
+// if Fetch was never called. Call it once on pipeline exit.
+func (r *Renderer) Close() {
+	if r.browserCancel != nil {
+		r.browserCancel()
+	}
+	if r.allocCancel != nil {
+		r.allocCancel()
+	}
+}
+
+// Fetch navigates to pageURL in the shared browser, waits for the DOM plus
+// (if configured) cfg.WaitSelector to become visible, and returns either
+// the largest in-viewport <img> meeting minImageEdge — its bytes,
+// downloaded through the page's own fetch() so its cookies and CSRF
+// tokens apply, plus its resolved src URL — or, failing that, a
+// screenshot of the page's main article region (resolvedURL echoes
+// pageURL in that case, since there's no single image URL to report).
+// Blocks until a worker-pool slot is free, bounding how many tabs run at
+// once to GOMAXPROCS.
+func (r *Renderer) Fetch(ctx context.Context, pageURL string) (data []byte, resolvedURL string, err error) {
+	r.enabledMu.RLock()
+	enabled := r.enabled
+	r.enabledMu.RUnlock()
+	if !enabled {
+		return nil, "", fmt.Errorf("webdriver: headless_browser is disabled")
+	}
+	r.startOnce.Do(r.start)
+
+	select {
+	case r.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+	defer func() { <-r.sem }()
+
+	timeout := time.Duration(r.cfg.TimeoutSec) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	tabCtx, cancelTab := chromedp.NewContext(r.browserCtx)
+	defer cancelTab()
+	tabCtx, cancelTimeout := context.WithTimeout(tabCtx, timeout)
+	defer cancelTimeout()
+
+	tasks := chromedp.Tasks{
+		chromedp.Navigate(pageURL),
+		chromedp.WaitReady("body", chromedp.ByQuery),
+	}
+	if r.cfg.WaitSelector != "" {
+		tasks = append(tasks, chromedp.WaitVisible(r.cfg.WaitSelector, chromedp.ByQuery))
+	}
+	// Give any remaining lazy-loaded images/XHRs a moment to settle after
+	// the DOM is ready, since chromedp has no built-in network-idle wait.
+	tasks = append(tasks, chromedp.Sleep(500*time.Millisecond))
+
+	if err := chromedp.Run(tabCtx, tasks); err != nil {
+		return nil, "", fmt.Errorf("webdriver: navigate %s: %w", pageURL, err)
+	}
+
+	if data, src, err := r.largestImage(tabCtx); err == nil {
+		return data, src, nil
+	}
+
+	data, err = r.screenshotArticle(tabCtx)
+	return data, pageURL, err
+}
+
+// largestImage finds the largest in-viewport <img> meeting minImageEdge on
+// the current page and downloads it through the page's own fetch(), so
+// the request carries whatever cookies/CSRF tokens the page session
+// already has. Returns the image's resolved src alongside its bytes, for
+// the caller to cache the bytes under.
+func (r *Renderer) largestImage(ctx context.Context) ([]byte, string, error) {
+	var src string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(largestImageJS, &src)); err != nil {
+		return nil, "", fmt.Errorf("webdriver: find largest image: %w", err)
+	}
+	if src == "" {
+		return nil, "", fmt.Errorf("webdriver: no in-viewport image found")
+	}
+
+	var b64 string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(fetchAsBase64JS(src), &b64, awaitPromise)); err != nil {
+		return nil, "", fmt.Errorf("webdriver: download %s via session: %w", src, err)
+	}
+	data, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return nil, "", fmt.Errorf("webdriver: decode %s: %w", src, err)
+	}
+	if len(data) < 1000 {
+		return nil, "", fmt.Errorf("webdriver: image %s too small (%d bytes)", src, len(data))
+	}
+	return data, src, nil
+}
+
+// screenshotArticle captures the page's <article>/<main> region, falling
+// back to a full-page screenshot if neither is present.
+func (r *Renderer) screenshotArticle(ctx context.Context) ([]byte, error) {
+	var buf []byte
+	err := chromedp.Run(ctx, chromedp.Screenshot("article, main, [role=main]", &buf, chromedp.NodeVisible, chromedp.ByQuery))
+	if err == nil && len(buf) > 0 {
+		return buf, nil
+	}
+	if err := chromedp.Run(ctx, chromedp.FullScreenshot(&buf, 90)); err != nil {
+		return nil, fmt.Errorf("webdriver: screenshot: %w", err)
+	}
+	return buf, nil
+}
+
+// awaitPromise tells chromedp.Evaluate to wait for a JS Promise's
+// resolution rather than returning the Promise object itself.
+func awaitPromise(p *cdpruntime.EvaluateParams) *cdpruntime.EvaluateParams {
+	return p.WithAwaitPromise(true)
+}
+
+// largestImageJS picks the <img> with the biggest in-viewport area among
+// those whose longest edge is at least minImageEdge CSS pixels — filtering
+// out icons, avatars and tracking pixels — using currentSrc so responsive
+// <picture>/srcset images resolve to whichever variant the browser
+// actually chose.
+var largestImageJS = fmt.Sprintf(`(() => {
+	const minEdge = %d;
+	const vw = window.innerWidth, vh = window.innerHeight;
+	let best = '', bestArea = 0;
+	for (const img of document.querySelectorAll('img')) {
+		const rect = img.getBoundingClientRect();
+		const inViewport = rect.bottom > 0 && rect.top < vh && rect.right > 0 && rect.left < vw;
+		if (!inViewport) continue;
+		if (Math.max(img.naturalWidth, img.naturalHeight) < minEdge) continue;
+		const area = rect.width * rect.height;
+		if (area > bestArea) {
+			bestArea = area;
+			best = img.currentSrc || img.src;
+		}
+	}
+	return best;
+})()`, minImageEdge)
+
+// fetchAsBase64JS builds a JS expression that re-fetches src from within
+// the page (carrying its cookies) and resolves to its base64-encoded bytes.
+func fetchAsBase64JS(src string) string {
+	return fmt.Sprintf(`(async () => {
+		const resp = await fetch(%q, {credentials: 'include'});
+		const buf = await resp.arrayBuffer();
+		const bytes = new Uint8Array(buf);
+		let binary = '';
+		for (let i = 0; i < bytes.byteLength; i++) binary += String.fromCharCode(bytes[i]);
+		return btoa(binary);
+	})()`, src)
+}
@@ -0,0 +1,423 @@
+package subtitles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/types"
+)
+
+// minWordDurationSec clamps very fast words so they stay on screen long enough to read.
+const minWordDurationSec = 0.12
+
+// whisperWord is one word-level timestamp from Whisper's --output_format json.
+type whisperWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// whisperSegment is one Whisper segment, carrying its word timestamps.
+type whisperSegment struct {
+	Start float64       `json:"start"`
+	End   float64       `json:"end"`
+	Text  string        `json:"text"`
+	Words []whisperWord `json:"words"`
+}
+
+type whisperJSON struct {
+	Segments []whisperSegment `json:"segments"`
+}
+
+// buildKaraokeASS parses Whisper's JSON transcript and writes a karaoke-style
+// ASS file where each chunk of 1-3 words is highlighted word-by-word as it is
+// spoken. It returns the ASS file path.
+func buildKaraokeASS(cfg *config.Config, whisperJSONPath, outPath string) (string, error) {
+	data, err := os.ReadFile(whisperJSONPath)
+	if err != nil {
+		return "", fmt.Errorf("read whisper json: %w", err)
+	}
+
+	var transcript whisperJSON
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return "", fmt.Errorf("parse whisper json: %w", err)
+	}
+
+	var words []whisperWord
+	for _, seg := range transcript.Segments {
+		words = append(words, seg.Words...)
+	}
+	return buildKaraokeASSFromWords(cfg, words, outPath)
+}
+
+// buildKaraokeASSFromWords is the shared core of buildKaraokeASS: it takes an
+// already-flat word list so callers with word timings that didn't come from
+// Whisper (e.g. a TTS provider's own alignment data) can skip transcription
+// entirely and still get the same karaoke rendering.
+func buildKaraokeASSFromWords(cfg *config.Config, words []whisperWord, outPath string) (string, error) {
+	var dialogue []string
+	for _, chunk := range groupWordsIntoChunks(words, cfg.Subtitles.MaxCharsPerLine) {
+		dialogue = append(dialogue, chunkToDialogueLines(chunk, cfg)...)
+	}
+
+	content := assHeader(cfg) + strings.Join(dialogue, "\n") + "\n"
+	if err := os.WriteFile(outPath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("write ass file: %w", err)
+	}
+	return outPath, nil
+}
+
+// buildSRTFromWords groups a flat word list into the same 1-3 word chunks
+// the karaoke path uses and writes them as a plain SRT, for providers that
+// return word timings but are configured for the "srt" subtitle style.
+func buildSRTFromWords(words []whisperWord, maxChars int, outPath string) (string, error) {
+	var sb strings.Builder
+	index := 1
+	for _, chunk := range groupWordsIntoChunks(words, maxChars) {
+		if len(chunk) == 0 {
+			continue
+		}
+		start := chunk[0].Start
+		end := chunk[len(chunk)-1].End
+		if end-start < minWordDurationSec {
+			end = start + minWordDurationSec
+		}
+
+		var text strings.Builder
+		for i, w := range chunk {
+			if i > 0 {
+				text.WriteString(" ")
+			}
+			text.WriteString(strings.TrimSpace(w.Word))
+		}
+
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n\n",
+			index, formatSRTTimestamp(start), formatSRTTimestamp(end), text.String())
+		index++
+	}
+
+	if err := os.WriteFile(outPath, []byte(sb.String()), 0644); err != nil {
+		return "", fmt.Errorf("write srt file: %w", err)
+	}
+	return outPath, nil
+}
+
+// wordsFromScript flattens every scene's WordTimings (relative to that
+// scene's own audio file) into one whisperWord list with timestamps
+// relative to the full narration track, offset by each scene's
+// TimestampStart. ok is false if any scene is missing word timings — a
+// partial transcript isn't good enough to skip Whisper.
+func wordsFromScript(script *types.Script) (words []whisperWord, ok bool) {
+	if script == nil || len(script.Scenes) == 0 {
+		return nil, false
+	}
+	for _, scene := range script.Scenes {
+		if len(scene.WordTimings) == 0 {
+			return nil, false
+		}
+		for _, w := range scene.WordTimings {
+			words = append(words, whisperWord{
+				Word:  w.Word,
+				Start: scene.TimestampStart + w.Start,
+				End:   scene.TimestampStart + w.End,
+			})
+		}
+	}
+	return words, true
+}
+
+// formatSRTTimestamp renders seconds as SRT's HH:MM:SS,mmm timestamp format.
+func formatSRTTimestamp(sec float64) string {
+	if sec < 0 {
+		sec = 0
+	}
+	totalMillis := int(math.Round(sec * 1000))
+	h := totalMillis / 3600000
+	m := (totalMillis / 60000) % 60
+	s := (totalMillis / 1000) % 60
+	ms := totalMillis % 1000
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", h, m, s, ms)
+}
+
+// groupWordsIntoChunks groups consecutive words into 1-3 word chunks that fit
+// within maxChars, the same line-wrap budget the SRT path uses.
+func groupWordsIntoChunks(words []whisperWord, maxChars int) [][]whisperWord {
+	var chunks [][]whisperWord
+	var cur []whisperWord
+	curLen := 0
+
+	for _, w := range words {
+		text := strings.TrimSpace(w.Word)
+		if text == "" {
+			continue
+		}
+		added := len(text) + 1 // +1 for the joining space
+		if len(cur) > 0 && (len(cur) >= 3 || curLen+added > maxChars) {
+			chunks = append(chunks, cur)
+			cur = nil
+			curLen = 0
+		}
+		cur = append(cur, w)
+		curLen += added
+	}
+	if len(cur) > 0 {
+		chunks = append(chunks, cur)
+	}
+	return chunks
+}
+
+// chunkToDialogueLines emits one Dialogue line per word in the chunk, each
+// spanning that word's (clamped) timestamp range, rendering the full chunk
+// text with only the active word popped in AccentColor/ActiveScale.
+func chunkToDialogueLines(chunk []whisperWord, cfg *config.Config) []string {
+	lines := make([]string, 0, len(chunk))
+	for i, active := range chunk {
+		start := active.Start
+		end := active.End
+		if end-start < minWordDurationSec {
+			end = start + minWordDurationSec
+		}
+		text := chunkText(chunk, i, cfg)
+		lines = append(lines, fmt.Sprintf(
+			"Dialogue: 0,%s,%s,Default,,0,0,0,,%s",
+			formatASSTimestamp(start), formatASSTimestamp(end), text,
+		))
+	}
+	return lines
+}
+
+// chunkText renders the chunk's words, wrapping the word at activeIdx in an
+// accent-colour/scale override and prefixing every word with a {\k<cs>}
+// karaoke tag (skipped for the "wordpop" style) as a fallback for players
+// that honour karaoke timing but not per-line overrides.
+func chunkText(chunk []whisperWord, activeIdx int, cfg *config.Config) string {
+	scalePct := int(math.Round(cfg.Subtitles.ActiveScale * 100))
+	if scalePct == 0 {
+		scalePct = 110
+	}
+
+	var sb strings.Builder
+	for i, w := range chunk {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		word := escapeASSText(strings.TrimSpace(w.Word))
+		if cfg.Subtitles.Style == "karaoke" {
+			cs := int(math.Round((w.End - w.Start) * 100))
+			if cs < 1 {
+				cs = 1
+			}
+			sb.WriteString(fmt.Sprintf("{\\k%d}", cs))
+		}
+		if i == activeIdx {
+			accent := cfg.Subtitles.AccentColor
+			if accent == "" {
+				accent = "&H0000FFFF&" // yellow fallback
+			}
+			sb.WriteString(fmt.Sprintf("{\\c%s\\fscx%d\\fscy%d}%s{\\r}", accent, scalePct, scalePct, word))
+		} else {
+			sb.WriteString(word)
+		}
+	}
+	return sb.String()
+}
+
+// escapeASSText neutralizes ASS override-block delimiters in source text so
+// narration containing literal braces can't inject unintended style tags.
+func escapeASSText(s string) string {
+	s = strings.ReplaceAll(s, "{", "｛")
+	s = strings.ReplaceAll(s, "}", "｝")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
+
+// formatASSTimestamp renders seconds as ASS's H:MM:SS.cc timestamp format.
+func formatASSTimestamp(sec float64) string {
+	if sec < 0 {
+		sec = 0
+	}
+	totalCentis := int(math.Round(sec * 100))
+	h := totalCentis / 360000
+	m := (totalCentis / 6000) % 60
+	s := (totalCentis / 100) % 60
+	cs := totalCentis % 100
+	return fmt.Sprintf("%d:%02d:%02d.%02d", h, m, s, cs)
+}
+
+// assColors resolves the Primary/Secondary/Outline ASS colours from config,
+// falling back to this file's original hardcoded defaults when unset.
+func assColors(cfg *config.Config) (primary, secondary, outline string) {
+	primary = cfg.Subtitles.Color
+	if primary == "" {
+		primary = "&H00C8C8C8&" // dim light-grey default
+	}
+	secondary = cfg.Subtitles.SecondaryColor
+	if secondary == "" {
+		secondary = "&H000000FF&"
+	}
+	outline = cfg.Subtitles.StrokeColor
+	if outline == "" {
+		outline = "&H00000000&"
+	}
+	return primary, secondary, outline
+}
+
+// assAlignment maps Subtitles.Position to an ASS numpad Alignment value.
+func assAlignment(position string) int {
+	switch position {
+	case "top":
+		return 8
+	case "middle", "center":
+		return 5
+	default:
+		return 2
+	}
+}
+
+// assStyleLine renders one [V4+ Styles] Style line named name, at vertical
+// offset marginV, using cfg's font/colour/outline/shadow/position settings —
+// shared by assHeader and assHeaderDualLanguage's second "Translation" style.
+func assStyleLine(name string, cfg *config.Config, marginV int) string {
+	bold := -1
+	if cfg.Subtitles.FontWeight != "bold" {
+		bold = 0
+	}
+	primary, secondary, outline := assColors(cfg)
+	return fmt.Sprintf("Style: %s,%s,%d,%s,%s,%s,&H80000000&,%d,0,0,0,100,100,0,0,1,%.0f,%.0f,%d,10,10,%d,1",
+		name, cfg.Subtitles.Font, cfg.Subtitles.FontSize, primary, secondary, outline, bold,
+		cfg.Subtitles.StrokeWidth, cfg.Subtitles.Shadow, assAlignment(cfg.Subtitles.Position), marginV,
+	)
+}
+
+// assHeader builds the [Script Info]/[V4+ Styles]/[Events] preamble. All
+// styling lives here since the ass= burn filter ignores force_style.
+func assHeader(cfg *config.Config) string {
+	return fmt.Sprintf(`[Script Info]
+ScriptType: v4.00+
+PlayResX: 1920
+PlayResY: 1080
+WrapStyle: 2
+ScaledBorderAndShadow: yes
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+%s
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`, assStyleLine("Default", cfg, cfg.Subtitles.MarginBottom))
+}
+
+// assHeaderDualLanguage is assHeader plus a second "Translation" style for
+// the translated track, its MarginV pushed roughly one line height above the
+// primary cue so the two tracks stack instead of overlapping.
+func assHeaderDualLanguage(cfg *config.Config) string {
+	fontSize := cfg.Subtitles.FontSize
+	if fontSize <= 0 {
+		fontSize = 48
+	}
+	translationMarginV := cfg.Subtitles.MarginBottom + int(float64(fontSize)*1.4)
+
+	return fmt.Sprintf(`[Script Info]
+ScriptType: v4.00+
+PlayResX: 1920
+PlayResY: 1080
+WrapStyle: 2
+ScaledBorderAndShadow: yes
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+%s
+%s
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`,
+		assStyleLine("Default", cfg, cfg.Subtitles.MarginBottom),
+		assStyleLine("Translation", cfg, translationMarginV),
+	)
+}
+
+// wordsToText joins a chunk's words into plain text for translateCues —
+// the karaoke Dialogue lines carry per-word ASS override tags that
+// shouldn't be sent to Groq as translation input.
+func wordsToText(chunk []whisperWord) string {
+	var sb strings.Builder
+	for i, w := range chunk {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(strings.TrimSpace(w.Word))
+	}
+	return sb.String()
+}
+
+// buildDualLanguageASS parses Whisper's JSON transcript and writes an ASS
+// file carrying the primary-language karaoke/wordpop track plus a second
+// "Translation" track translated via Groq, stacked above it. It returns the
+// ASS file path.
+func buildDualLanguageASSFromJSON(ctx context.Context, cfg *config.Config, whisperJSONPath, outPath string) (string, error) {
+	data, err := os.ReadFile(whisperJSONPath)
+	if err != nil {
+		return "", fmt.Errorf("read whisper json: %w", err)
+	}
+
+	var transcript whisperJSON
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return "", fmt.Errorf("parse whisper json: %w", err)
+	}
+
+	var words []whisperWord
+	for _, seg := range transcript.Segments {
+		words = append(words, seg.Words...)
+	}
+	return buildDualLanguageASS(ctx, cfg, words, outPath)
+}
+
+// buildDualLanguageASS is buildDualLanguageASSFromJSON's shared core: given
+// an already-flat word list, it builds the primary track's Dialogue lines
+// the same way buildKaraokeASSFromWords does, translates each cue in one
+// batched Groq call, and appends the translated cues as a second
+// "Translation" track.
+func buildDualLanguageASS(ctx context.Context, cfg *config.Config, words []whisperWord, outPath string) (string, error) {
+	chunks := groupWordsIntoChunks(words, cfg.Subtitles.MaxCharsPerLine)
+
+	var primary []string
+	var cueTexts []string
+	for _, chunk := range chunks {
+		primary = append(primary, chunkToDialogueLines(chunk, cfg)...)
+		cueTexts = append(cueTexts, wordsToText(chunk))
+	}
+
+	translated, err := translateCues(ctx, cfg, cueTexts, cfg.Subtitles.DualLanguage.Language)
+	if err != nil {
+		return "", fmt.Errorf("translate cues: %w", err)
+	}
+
+	var secondary []string
+	for i, chunk := range chunks {
+		if len(chunk) == 0 {
+			continue
+		}
+		start := chunk[0].Start
+		end := chunk[len(chunk)-1].End
+		if end-start < minWordDurationSec {
+			end = start + minWordDurationSec
+		}
+		secondary = append(secondary, fmt.Sprintf(
+			"Dialogue: 0,%s,%s,Translation,,0,0,0,,%s",
+			formatASSTimestamp(start), formatASSTimestamp(end), escapeASSText(translated[i]),
+		))
+	}
+
+	content := assHeaderDualLanguage(cfg) + strings.Join(primary, "\n") + "\n" + strings.Join(secondary, "\n") + "\n"
+	if err := os.WriteFile(outPath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("write ass file: %w", err)
+	}
+	return outPath, nil
+}
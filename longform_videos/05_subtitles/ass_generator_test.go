@@ -0,0 +1,80 @@
+package subtitles
+
+import "testing"
+
+func TestFormatASSTimestamp(t *testing.T) {
+	cases := []struct {
+		sec  float64
+		want string
+	}{
+		{0, "0:00:00.00"},
+		{-5, "0:00:00.00"},
+		{1.5, "0:00:01.50"},
+		{61.004, "0:01:01.00"},
+		{3661.999, "1:01:02.00"},
+	}
+	for _, c := range cases {
+		if got := formatASSTimestamp(c.sec); got != c.want {
+			t.Errorf("formatASSTimestamp(%v) = %q, want %q", c.sec, got, c.want)
+		}
+	}
+}
+
+func TestFormatSRTTimestamp(t *testing.T) {
+	cases := []struct {
+		sec  float64
+		want string
+	}{
+		{0, "00:00:00,000"},
+		{-5, "00:00:00,000"},
+		{1.5, "00:00:01,500"},
+		{3661.2345, "01:01:01,235"},
+	}
+	for _, c := range cases {
+		if got := formatSRTTimestamp(c.sec); got != c.want {
+			t.Errorf("formatSRTTimestamp(%v) = %q, want %q", c.sec, got, c.want)
+		}
+	}
+}
+
+func TestEscapeASSText(t *testing.T) {
+	got := escapeASSText("he said {\\i1}hi{\\i0}\nreally")
+	want := "he said ｛\\i1｝hi｛\\i0｝ really"
+	if got != want {
+		t.Fatalf("escapeASSText = %q, want %q", got, want)
+	}
+}
+
+func TestGroupWordsIntoChunksRespectsMaxCharsAndWordCap(t *testing.T) {
+	words := []whisperWord{
+		{Word: "the"}, {Word: "quick"}, {Word: "brown"}, {Word: "fox"}, {Word: "jumps"},
+	}
+	chunks := groupWordsIntoChunks(words, 12)
+	if len(chunks) == 0 {
+		t.Fatalf("expected at least one chunk")
+	}
+	for _, c := range chunks {
+		if len(c) > 3 {
+			t.Errorf("chunk %v exceeds the 3-word cap", c)
+		}
+	}
+	var total int
+	for _, c := range chunks {
+		total += len(c)
+	}
+	if total != len(words) {
+		t.Fatalf("chunks account for %d words, want %d (none should be dropped)", total, len(words))
+	}
+}
+
+func TestGroupWordsIntoChunksSkipsBlankWords(t *testing.T) {
+	words := []whisperWord{{Word: "hello"}, {Word: "  "}, {Word: "world"}}
+	chunks := groupWordsIntoChunks(words, 100)
+	var total int
+	for _, c := range chunks {
+		total += len(c)
+	}
+	if total != 2 {
+		t.Fatalf("blank-only word should be skipped, got %d words across chunks", total)
+	}
+}
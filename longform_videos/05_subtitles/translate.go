@@ -0,0 +1,160 @@
+package subtitles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/config"
+)
+
+// translateBatchSize caps how many cues go into one Groq call. A longform
+// video can produce hundreds of 1-3 word cues; batching keeps each request
+// well within translateRequest's MaxTokens instead of risking a truncated
+// response on the whole track.
+const translateBatchSize = 40
+
+const translateSystemPrompt = `You translate short video subtitle cues. You MUST respond with ONLY a JSON object mapping each input key to its translated value, the same keys as the input object — no markdown, no explanation, no preamble.`
+
+type translateRequest struct {
+	Model       string             `json:"model"`
+	Messages    []translateMessage `json:"messages"`
+	Temperature float64            `json:"temperature"`
+	MaxTokens   int                `json:"max_tokens"`
+}
+
+type translateMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type translateResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// translateCues translates cues (each a short 1-3 word subtitle chunk) into
+// language (e.g. "Spanish" or a BCP-47 code), batching translateBatchSize
+// cues per Groq call so a longform video's full cue list doesn't risk
+// truncating a single oversized request.
+func translateCues(ctx context.Context, cfg *config.Config, cues []string, language string) ([]string, error) {
+	apiKey := os.Getenv("GROQ_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("GROQ_API_KEY not set")
+	}
+	model := cfg.Subtitles.DualLanguage.GroqModel
+	if model == "" {
+		model = "llama-3.1-8b-instant"
+	}
+
+	translated := make([]string, 0, len(cues))
+	for start := 0; start < len(cues); start += translateBatchSize {
+		end := start + translateBatchSize
+		if end > len(cues) {
+			end = len(cues)
+		}
+		batch, err := translateBatch(ctx, apiKey, model, language, cues[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("translate cues %d-%d: %w", start, end, err)
+		}
+		translated = append(translated, batch...)
+	}
+	return translated, nil
+}
+
+// translateBatch translates one batch of cues in a single Groq call. Cues
+// are sent and expected back as a JSON object keyed by index within the
+// batch ("0", "1", ...) rather than a bare array, so a response that drops,
+// reorders, or merges a cue surfaces as a missing key instead of silently
+// zipping a translation onto the wrong timestamp.
+func translateBatch(ctx context.Context, apiKey, model, language string, cues []string) ([]string, error) {
+	keyed := make(map[string]string, len(cues))
+	for i, cue := range cues {
+		keyed[fmt.Sprint(i)] = cue
+	}
+	cuesJSON, err := json.Marshal(keyed)
+	if err != nil {
+		return nil, err
+	}
+	userPrompt := fmt.Sprintf("Translate each cue's value into %s. Cues (JSON object, key -> text):\n%s", language, cuesJSON)
+
+	reqBody := translateRequest{
+		Model: model,
+		Messages: []translateMessage{
+			{Role: "system", Content: translateSystemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		Temperature: 0.3,
+		MaxTokens:   2048,
+	}
+	bodyBytes, _ := json.Marshal(reqBody)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.groq.com/openai/v1/chat/completions", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("groq translate request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var groqResp translateResponse
+	if err := json.Unmarshal(respBytes, &groqResp); err != nil {
+		return nil, fmt.Errorf("parse groq translate response: %w", err)
+	}
+	if groqResp.Error != nil {
+		return nil, fmt.Errorf("groq error: %s", groqResp.Error.Message)
+	}
+	if len(groqResp.Choices) == 0 {
+		return nil, fmt.Errorf("groq returned no choices")
+	}
+
+	content := cleanTranslateJSON(groqResp.Choices[0].Message.Content)
+	var translatedMap map[string]string
+	if err := json.Unmarshal([]byte(content), &translatedMap); err != nil {
+		return nil, fmt.Errorf("parse translated cues: %w", err)
+	}
+
+	result := make([]string, len(cues))
+	for i := range cues {
+		key := fmt.Sprint(i)
+		text, ok := translatedMap[key]
+		if !ok {
+			return nil, fmt.Errorf("missing translated cue for key %q", key)
+		}
+		result[i] = text
+	}
+	return result, nil
+}
+
+// cleanTranslateJSON strips markdown fences if Groq wraps its response in
+// ```json ... ```, mirroring 02_script's cleanJSON.
+func cleanTranslateJSON(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "```json")
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimSuffix(s, "```")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,242 @@
+package subtitles
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/hwaccel"
+	"true-crime-pipeline/progress"
+	"true-crime-pipeline/types"
+)
+
+// Generator handles subtitle generation and burning
+type Generator struct {
+	cfg      *config.Config
+	reporter progress.Reporter
+}
+
+// New creates a new subtitle Generator. reporter may be nil, in which case
+// progress events are discarded.
+func New(cfg *config.Config, reporter progress.Reporter) *Generator {
+	if reporter == nil {
+		reporter = progress.NopReporter{}
+	}
+	return &Generator{cfg: cfg, reporter: reporter}
+}
+
+// Run produces a subtitle file for audioFile. If every scene in script
+// already carries WordTimings — e.g. the TTS provider returned its own
+// alignment data (see 03_audio/providers) — Whisper is skipped entirely and
+// the subtitle file is built directly from those timings. Otherwise it
+// transcribes with Whisper as before: a plain SRT for the default "srt"
+// style, word timestamps synthesized into a karaoke-style ASS file (see
+// ass_generator.go) for "karaoke"/"wordpop", or — when
+// Subtitles.DualLanguage is enabled — an ASS file carrying that primary
+// track plus a second Groq-translated track stacked above it, regardless of
+// Style.
+func (g *Generator) Run(ctx context.Context, script *types.Script, audioFile, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", err
+	}
+
+	wordLevel := g.cfg.Subtitles.Style == "karaoke" || g.cfg.Subtitles.Style == "wordpop"
+	dualLanguage := g.cfg.Subtitles.DualLanguage.Enabled
+
+	if words, ok := wordsFromScript(script); ok {
+		log.Println("[subtitles] Provider supplied word timings — skipping Whisper")
+		if dualLanguage {
+			assFile := filepath.Join(outputDir, "subtitles.ass")
+			assFile, err := buildDualLanguageASS(ctx, g.cfg, words, assFile)
+			if err != nil {
+				return "", fmt.Errorf("build dual-language ASS: %w", err)
+			}
+			log.Printf("[subtitles] ✅ Dual-language ASS generated: %s", assFile)
+			return assFile, nil
+		}
+		if wordLevel {
+			assFile := filepath.Join(outputDir, "subtitles.ass")
+			assFile, err := buildKaraokeASSFromWords(g.cfg, words, assFile)
+			if err != nil {
+				return "", fmt.Errorf("build karaoke ASS: %w", err)
+			}
+			log.Printf("[subtitles] ✅ ASS generated: %s", assFile)
+			return assFile, nil
+		}
+		srtFile := filepath.Join(outputDir, "subtitles.srt")
+		srtFile, err := buildSRTFromWords(words, g.cfg.Subtitles.MaxCharsPerLine, srtFile)
+		if err != nil {
+			return "", fmt.Errorf("build srt from word timings: %w", err)
+		}
+		log.Printf("[subtitles] ✅ SRT generated: %s", srtFile)
+		return srtFile, nil
+	}
+
+	log.Println("[subtitles] Running Whisper transcription...")
+
+	outputFormat := "srt"
+	if wordLevel || dualLanguage {
+		outputFormat = "json"
+	}
+
+	audioDur, _ := getMediaDuration(audioFile)
+
+	// Run whisper CLI, tailing its stderr timestamps for percent/ETA.
+	// whisper audio.mp3 --model base --output_format srt --output_dir /path/
+	err := progress.RunWhisper(ctx, audioDur, g.reporter,
+		audioFile,
+		"--model", g.cfg.Subtitles.WhisperModel,
+		"--output_format", outputFormat,
+		"--output_dir", outputDir,
+		"--language", "en",
+		"--word_timestamps", "True",
+		"--max_line_width", fmt.Sprintf("%d", g.cfg.Subtitles.MaxCharsPerLine),
+		"--max_line_count", "2",
+	)
+	if err != nil {
+		return "", fmt.Errorf("whisper failed: %w", err)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(audioFile), filepath.Ext(audioFile))
+
+	if dualLanguage {
+		whisperJSON := filepath.Join(outputDir, base+".json")
+		assFile := filepath.Join(outputDir, "subtitles.ass")
+		assFile, err := buildDualLanguageASSFromJSON(ctx, g.cfg, whisperJSON, assFile)
+		if err != nil {
+			return "", fmt.Errorf("build dual-language ASS: %w", err)
+		}
+		log.Printf("[subtitles] ✅ Dual-language ASS generated: %s", assFile)
+		return assFile, nil
+	}
+
+	if wordLevel {
+		whisperJSON := filepath.Join(outputDir, base+".json")
+		assFile := filepath.Join(outputDir, "subtitles.ass")
+		assFile, err := buildKaraokeASS(g.cfg, whisperJSON, assFile)
+		if err != nil {
+			return "", fmt.Errorf("build karaoke ASS: %w", err)
+		}
+		log.Printf("[subtitles] ✅ ASS generated: %s", assFile)
+		return assFile, nil
+	}
+
+	// Whisper saves as <audioFilename>.srt — find it
+	srtFile := filepath.Join(outputDir, "subtitles.srt")
+	whisperOut := filepath.Join(outputDir, base+".srt")
+	if _, err := os.Stat(whisperOut); err == nil && whisperOut != srtFile {
+		if err := os.Rename(whisperOut, srtFile); err != nil {
+			srtFile = whisperOut // use the whisper path directly
+		}
+	}
+
+	log.Printf("[subtitles] ✅ SRT generated: %s", srtFile)
+	return srtFile, nil
+}
+
+// BurnIntoVideo uses FFmpeg to burn subtitles directly into the video. A
+// ".ass" file is burned with the `ass=` filter so its own [V4+ Styles]
+// section (including per-word karaoke overrides) is honored — `ass=` ignores
+// force_style entirely, unlike `subtitles=`.
+func (g *Generator) BurnIntoVideo(ctx context.Context, videoFile, srtFile, outputDir string) (string, error) {
+	log.Println("[subtitles] Burning subtitles into video...")
+
+	outFile := filepath.Join(outputDir, "video_subtitled.mp4")
+
+	var subtitleFilter string
+	if strings.EqualFold(filepath.Ext(srtFile), ".ass") {
+		subtitleFilter = fmt.Sprintf("ass=%s", escapeSubtitlePath(srtFile))
+	} else {
+		// Build FFmpeg subtitle filter with styling
+		subtitleFilter = fmt.Sprintf(
+			"subtitles=%s:force_style='FontName=%s,FontSize=%d,Bold=%d,PrimaryColour=&H00FFFFFF,OutlineColour=&H00000000,Outline=%.0f,Alignment=2,MarginV=%d'",
+			escapeSubtitlePath(srtFile),
+			g.cfg.Subtitles.Font,
+			g.cfg.Subtitles.FontSize,
+			boolToInt(g.cfg.Subtitles.FontWeight == "bold"),
+			g.cfg.Subtitles.StrokeWidth,
+			g.cfg.Subtitles.MarginBottom,
+		)
+	}
+
+	enc := hwaccel.Resolve(hwaccel.Options{
+		Encoder:  hwaccel.Encoder(g.cfg.Visuals.Encoder),
+		Quality:  g.cfg.Visuals.Quality,
+		HWDevice: g.cfg.Visuals.HWDevice,
+	})
+	preInput, videoArgs := hwaccel.EncoderArgs(enc, hwaccel.Options{Quality: g.cfg.Visuals.Quality})
+	// VAAPI must run the subtitle filter on CPU frames before hwupload.
+	subtitleFilter += hwaccel.VideoFilterSuffix(enc)
+
+	videoDur, _ := getMediaDuration(videoFile)
+
+	args := []string{"-y"}
+	args = append(args, preInput...)
+	args = append(args, "-i", videoFile, "-vf", subtitleFilter)
+	args = append(args, videoArgs...)
+	args = append(args, "-c:a", "copy", outFile)
+
+	if err := progress.RunFFmpeg(ctx, "subtitle_burn", 0, videoDur, g.reporter, args...); err != nil {
+		return "", fmt.Errorf("ffmpeg subtitle burn: %w", err)
+	}
+
+	log.Printf("[subtitles] ✅ Subtitles burned: %s", outFile)
+	return outFile, nil
+}
+
+// ValidateSRT checks that the SRT file is valid and non-empty
+func ValidateSRT(srtFile string) error {
+	f, err := os.Open(srtFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineCount := 0
+	for scanner.Scan() {
+		lineCount++
+	}
+
+	if lineCount < 4 {
+		return fmt.Errorf("SRT file appears empty or malformed (%d lines)", lineCount)
+	}
+	return nil
+}
+
+func escapeSubtitlePath(path string) string {
+	// FFmpeg subtitle filter needs escaped colons and backslashes
+	path = strings.ReplaceAll(path, "\\", "/")
+	path = strings.ReplaceAll(path, ":", "\\:")
+	return path
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// getMediaDuration uses ffprobe to get a media file's duration in seconds,
+// used as the target duration for progress percent calculations.
+func getMediaDuration(path string) (float64, error) {
+	out, err := exec.Command("ffprobe",
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		path,
+	).Output()
+	if err != nil {
+		return 0, err
+	}
+	var dur float64
+	_, err = fmt.Sscanf(strings.TrimSpace(string(out)), "%f", &dur)
+	return dur, err
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"true-crime-pipeline/config"
+	"true-crime-pipeline/hwaccel"
 	"true-crime-pipeline/types"
 )
 
@@ -23,7 +24,9 @@ func New(cfg *config.Config) *Renderer {
 	return &Renderer{cfg: cfg}
 }
 
-// Run builds the final video: visuals + audio + proof animations + SFX
+// Run builds the final video: visuals + proof animations, combined with
+// audioFile. audioFile is expected to already carry any SFX mixing (see
+// sfx.Matcher.MixWithNarration) — Renderer no longer mixes SFX itself.
 func (r *Renderer) Run(ctx context.Context, script *types.Script, audioFile, outputDir string) (string, error) {
 	log.Println("[render] Starting final video assembly...")
 
@@ -40,15 +43,8 @@ func (r *Renderer) Run(ctx context.Context, script *types.Script, audioFile, out
 		proofVideo = silentVideo
 	}
 
-	// Step 3: Mix narration audio + SFX
-	mixedAudio, err := r.mixAudio(ctx, script, audioFile, outputDir)
-	if err != nil {
-		log.Printf("[render] Warning: SFX mix failed: %v — using narration only", err)
-		mixedAudio = audioFile
-	}
-
-	// Step 4: Combine video + audio into final MP4
-	finalVideo, err := r.combineVideoAudio(ctx, proofVideo, mixedAudio, outputDir)
+	// Step 3: Combine video + audio into final MP4
+	finalVideo, err := r.combineVideoAudio(ctx, proofVideo, audioFile, outputDir)
 	if err != nil {
 		return "", fmt.Errorf("combine video+audio: %w", err)
 	}
@@ -77,20 +73,34 @@ func (r *Renderer) concatenateVisuals(ctx context.Context, script *types.Script,
 		return "", err
 	}
 
+	// 22 preserves this stage's pre-hwaccel CRF default for the CPU path
+	// when Quality is unset (0), rather than silently adopting hwaccel's
+	// own generic default of 20.
+	quality := r.cfg.Visuals.Quality
+	if quality == 0 {
+		quality = 22
+	}
+	enc := hwaccel.Resolve(hwaccel.Options{
+		Encoder:  hwaccel.Encoder(r.cfg.Visuals.Encoder),
+		Quality:  quality,
+		HWDevice: r.cfg.Visuals.HWDevice,
+	})
+	preInput, videoArgs := hwaccel.EncoderArgs(enc, hwaccel.Options{Quality: quality})
+	scaleFilter := "scale=1920:1080:force_original_aspect_ratio=decrease,pad=1920:1080:(ow-iw)/2:(oh-ih)/2,setsar=1" + hwaccel.VideoFilterSuffix(enc)
+
 	outFile := filepath.Join(outputDir, "visuals_raw.mp4")
-	cmd := exec.CommandContext(ctx, "ffmpeg", "-y",
-		"-f", "concat",
-		"-safe", "0",
-		"-i", listFile,
-		"-c:v", "libx264",
-		"-preset", "fast",
-		"-crf", "22",
-		"-vf", "scale=1920:1080:force_original_aspect_ratio=decrease,pad=1920:1080:(ow-iw)/2:(oh-ih)/2,setsar=1",
+	args := []string{"-y"}
+	args = append(args, preInput...)
+	args = append(args, "-f", "concat", "-safe", "0", "-i", listFile, "-vf", scaleFilter)
+	args = append(args, videoArgs...)
+	args = append(args,
 		"-r", fmt.Sprintf("%d", r.cfg.Visuals.FPS),
 		"-pix_fmt", "yuv420p",
 		"-an",
 		outFile,
 	)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -207,88 +217,44 @@ func (r *Renderer) applyOneProofOverlay(ctx context.Context, videoFile string, s
 		return "", fmt.Errorf("scale proof image: %w", err)
 	}
 
-	cmd := exec.CommandContext(ctx, "ffmpeg", "-y",
-		"-i", videoFile,
-		"-i", scaledProof,
-		"-filter_complex", proofFilter,
-		"-map", "[out]",
-		"-c:v", "libx264",
-		"-preset", "fast",
-		"-crf", "22",
-		"-pix_fmt", "yuv420p",
-		"-an",
-		outFile,
-	)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("ffmpeg proof overlay: %w", err)
-	}
-	return outFile, nil
-}
-
-// mixAudio merges narration audio with SFX tracks
-func (r *Renderer) mixAudio(ctx context.Context, script *types.Script, narrationFile, outputDir string) (string, error) {
-	// Collect all SFX files with their timing
-	var sfxInputs []string
-	var sfxFilters []string
-	inputIdx := 1 // 0 is narration
-
-	for _, scene := range script.Scenes {
-		if scene.SFXFile == "" {
-			continue
-		}
-		sfxInputs = append(sfxInputs, "-i", scene.SFXFile)
-
-		// Delay SFX to scene start time
-		delayMs := int(scene.TimestampStart * 1000)
-		sfxFilters = append(sfxFilters,
-			fmt.Sprintf("[%d:a]adelay=%d|%d[sfx%d]", inputIdx, delayMs, delayMs, inputIdx),
-		)
-		inputIdx++
+	// 22 preserves this stage's pre-hwaccel CRF default for the CPU path
+	// when Quality is unset (0), rather than silently adopting hwaccel's
+	// own generic default of 20.
+	quality := r.cfg.Visuals.Quality
+	if quality == 0 {
+		quality = 22
 	}
-
-	outFile := filepath.Join(outputDir, "audio_mixed.mp3")
-
-	if len(sfxInputs) == 0 {
-		// No SFX — just copy narration
-		cmd := exec.CommandContext(ctx, "ffmpeg", "-y", "-i", narrationFile, "-c:a", "copy", outFile)
-		return outFile, cmd.Run()
-	}
-
-	// Build amix filter
-	var mixInputs []string
-	mixInputs = append(mixInputs, "[0:a]")
-	for i := range sfxFilters {
-		mixInputs = append(mixInputs, fmt.Sprintf("[sfx%d]", i+1))
-	}
-
-	filterComplex := strings.Join(sfxFilters, ";")
-	filterComplex += ";" + strings.Join(mixInputs, "") +
-		fmt.Sprintf("amix=inputs=%d:duration=first:normalize=0[aout]", len(mixInputs))
-
-	args := []string{"-y", "-i", narrationFile}
-	args = append(args, sfxInputs...)
-	args = append(args,
-		"-filter_complex", filterComplex,
-		"-map", "[aout]",
-		"-c:a", "libmp3lame",
-		"-q:a", "2",
-		outFile,
-	)
+	enc := hwaccel.Resolve(hwaccel.Options{
+		Encoder:  hwaccel.Encoder(r.cfg.Visuals.Encoder),
+		Quality:  quality,
+		HWDevice: r.cfg.Visuals.HWDevice,
+	})
+	preInput, videoArgs := hwaccel.EncoderArgs(enc, hwaccel.Options{Quality: quality})
+	// VAAPI must run the overlay filter_complex on CPU frames before
+	// hwupload, same as whisper_subtitles' subtitle burn — append the
+	// suffix to the filter graph's final labeled output.
+	proofFilter = strings.TrimSuffix(proofFilter, "[out]") + hwaccel.VideoFilterSuffix(enc) + "[out]"
+
+	args := []string{"-y"}
+	args = append(args, preInput...)
+	args = append(args, "-i", videoFile, "-i", scaledProof, "-filter_complex", proofFilter, "-map", "[out]")
+	args = append(args, videoArgs...)
+	args = append(args, "-pix_fmt", "yuv420p", "-an", outFile)
 
 	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("ffmpeg audio mix: %w", err)
+		return "", fmt.Errorf("ffmpeg proof overlay: %w", err)
 	}
 	return outFile, nil
 }
 
-// combineVideoAudio merges the final video and audio into one MP4
+// combineVideoAudio merges the final video and audio into one MP4. The
+// video stream is a plain container copy (it was already encoded by
+// concatenateVisuals/applyOneProofOverlay), so there's no encoder here for
+// hwaccel to pick — only the audio track gets re-encoded.
 func (r *Renderer) combineVideoAudio(ctx context.Context, videoFile, audioFile, outputDir string) (string, error) {
 	log.Println("[render] Combining video + audio...")
 
@@ -16,26 +16,40 @@ import (
 	"true-crime-pipeline/types"
 )
 
-const metadataSystemPrompt = `You are an expert YouTube SEO strategist and true crime content specialist.
+const metadataSystemPromptTemplate = `You are an expert YouTube SEO strategist and true crime content specialist.
 Generate compelling YouTube metadata that maximizes click-through rate and search ranking.
 
 You MUST respond with ONLY valid JSON — no markdown, no explanation, no preamble.
 
 The JSON must have exactly these fields:
 - "title": string (max 70 chars, must be click-bait but honest, true crime hook style)
+- "title_variants": array of %d strings — alternate titles for the SAME video, each a genuinely different hook/angle (not a minor rewording), for A/B thumbnail testing. The first entry must equal "title".
 - "description": string (~500 words, SEO-rich, includes timestamps placeholder, source credits, channel CTA)
 - "tags": array of 30 strings (mix of broad and specific tags)
 - "thumbnail_prompt": string (detailed prompt for a dramatic thumbnail image)
 
 Title formulas that work for true crime:
 - "She [did X]. Nobody Knew [shocking fact]."
-- "The [Person] Who [shocking action]. The Truth Will Disturb You."  
+- "The [Person] Who [shocking action]. The Truth Will Disturb You."
 - "[Number] Days. [Number] Victims. Nobody Suspected [person]."
 - "He Was A [trusted role]. Then They Found [evidence]."
 - "The [Case] That Still Has No Answers."
 
 Thumbnail prompt should describe: dramatic face/scene, high contrast, dark tones, text overlay area, eye-catching.`
 
+// metadataSystemPromptFor fills metadataSystemPromptTemplate's variant
+// count and appends a language instruction when language (a BCP-47 code
+// like "es") is set, for Localization's additional-language variants.
+// thumbnail_prompt stays untranslated either way since it only ever feeds
+// an image generator, never a viewer.
+func metadataSystemPromptFor(titleVariants int, language string) string {
+	prompt := fmt.Sprintf(metadataSystemPromptTemplate, titleVariants)
+	if language == "" {
+		return prompt
+	}
+	return prompt + fmt.Sprintf("\n\nWrite \"title\" and \"title_variants\" and \"description\" in %s. Keep \"thumbnail_prompt\" in English.", language)
+}
+
 // Generator creates YouTube metadata via Groq
 type Generator struct {
 	cfg        *config.Config
@@ -52,13 +66,16 @@ func New(cfg *config.Config) *Generator {
 
 type metadataJSON struct {
 	Title           string   `json:"title"`
+	TitleVariants   []string `json:"title_variants"`
 	Description     string   `json:"description"`
 	Tags            []string `json:"tags"`
 	ThumbnailPrompt string   `json:"thumbnail_prompt"`
 }
 
-// Run generates all YouTube metadata for the video
-func (g *Generator) Run(ctx context.Context, script *types.Script, story *types.Story) (*types.VideoMetadata, error) {
+// Run generates all YouTube metadata for the video, translated into
+// language (a BCP-47 code like "es") when set; an empty language keeps
+// this pipeline's default English metadata.
+func (g *Generator) Run(ctx context.Context, script *types.Script, story *types.Story, language string) (*types.VideoMetadata, error) {
 	log.Println("[metadata] Generating YouTube metadata via Groq...")
 
 	apiKey := os.Getenv("GROQ_API_KEY")
@@ -68,10 +85,15 @@ func (g *Generator) Run(ctx context.Context, script *types.Script, story *types.
 
 	userPrompt := buildMetadataPrompt(script, story, g.cfg)
 
+	titleVariants := g.cfg.Metadata.TitleVariants
+	if titleVariants <= 0 {
+		titleVariants = 3
+	}
+
 	reqBody := map[string]interface{}{
 		"model": g.cfg.Metadata.GroqModel,
 		"messages": []map[string]string{
-			{"role": "system", "content": metadataSystemPrompt},
+			{"role": "system", "content": metadataSystemPromptFor(titleVariants, language)},
 			{"role": "user", "content": userPrompt},
 		},
 		"temperature": 0.8,
@@ -126,29 +148,47 @@ func (g *Generator) Run(ctx context.Context, script *types.Script, story *types.
 	}
 
 	// Enforce title length
-	title := raw.Title
-	if len(title) > g.cfg.Metadata.TitleMaxChars {
-		title = title[:g.cfg.Metadata.TitleMaxChars-3] + "..."
+	title := enforceTitleMaxChars(raw.Title, g.cfg.Metadata.TitleMaxChars)
+
+	variants := raw.TitleVariants
+	if len(variants) == 0 {
+		variants = []string{raw.Title}
+	}
+	for i, v := range variants {
+		variants[i] = enforceTitleMaxChars(v, g.cfg.Metadata.TitleMaxChars)
 	}
+	variants[0] = title
 
 	// Calculate scheduled upload time (2PM EST on next Tue or Fri)
 	scheduledTime := nextUploadTime()
 
 	metadata := &types.VideoMetadata{
 		Title:            title,
+		TitleVariants:    variants,
 		Description:      raw.Description,
 		Tags:             raw.Tags[:min(30, len(raw.Tags))],
 		ThumbnailPrompt:  raw.ThumbnailPrompt,
 		CategoryID:       g.cfg.Metadata.YouTubeCategoryID,
 		Visibility:       g.cfg.Upload.Visibility,
 		ScheduledTimeUTC: scheduledTime,
+		Language:         language,
 	}
 
 	log.Printf("[metadata] ✅ Title: %q", metadata.Title)
-	log.Printf("[metadata] Tags: %d generated", len(metadata.Tags))
+	log.Printf("[metadata] %d title variants, %d tags generated", len(metadata.TitleVariants), len(metadata.Tags))
 	return metadata, nil
 }
 
+// enforceTitleMaxChars truncates title to maxChars (appending "...") if
+// it's too long; maxChars <= 0 leaves title untouched, since Metadata
+// config doesn't apply a "0 means default" guard to this field.
+func enforceTitleMaxChars(title string, maxChars int) string {
+	if maxChars <= 0 || len(title) <= maxChars {
+		return title
+	}
+	return title[:maxChars-3] + "..."
+}
+
 func buildMetadataPrompt(script *types.Script, story *types.Story, cfg *config.Config) string {
 	var sb strings.Builder
 	sb.WriteString("Generate YouTube metadata for this true crime video.\n\n")
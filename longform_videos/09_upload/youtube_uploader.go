@@ -0,0 +1,610 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/types"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	// resumableUploadEndpoint kicks off a YouTube Data API v3 resumable
+	// upload session; the response's Location header is the per-session
+	// URL every chunk PUT goes to.
+	resumableUploadEndpoint = "https://www.googleapis.com/upload/youtube/v3/videos?uploadType=resumable&part=snippet,status"
+	// videosUpdateEndpoint is videos.update scoped to just the
+	// localizations part, used by SetLocalization.
+	videosUpdateEndpoint = "https://www.googleapis.com/youtube/v3/videos?part=localizations"
+	// thumbnailsSetEndpoint is thumbnails.set, used by SetThumbnail.
+	thumbnailsSetEndpoint = "https://www.googleapis.com/upload/youtube/v3/thumbnails/set"
+	youtubeUploadScope    = "https://www.googleapis.com/auth/youtube.upload"
+	youtubeScope          = "https://www.googleapis.com/auth/youtube"
+
+	defaultChunkSizeMB = 8
+	maxChunkRetries    = 6
+)
+
+// Uploader drives a resumable YouTube Data API v3 upload directly over
+// HTTP (initial POST for an upload URL, then chunked PUTs with
+// Content-Range headers) instead of google-api-go-client's
+// Videos.Insert().Media(), so a network blip partway through a large
+// render doesn't force a full re-upload: progress is checkpointed to disk
+// after every chunk, and re-running with the same runID resumes from the
+// last confirmed byte.
+type Uploader struct {
+	cfg       *config.Config
+	runID     string
+	chunkSize int64
+
+	// ProgressCallback, if set, is invoked after every chunk with bytes
+	// sent so far and the total upload size, so a future TUI/webhook can
+	// surface upload progress.
+	ProgressCallback func(sent, total int64)
+}
+
+// New creates an Uploader for one pipeline run. runID keys its resume
+// checkpoint file under cfg.Paths.Logs.
+func New(cfg *config.Config, runID string) *Uploader {
+	chunkMB := cfg.Upload.ChunkSizeMB
+	if chunkMB <= 0 {
+		chunkMB = defaultChunkSizeMB
+	}
+	return &Uploader{cfg: cfg, runID: runID, chunkSize: int64(chunkMB) * 1024 * 1024}
+}
+
+// videoMetadata mirrors the subset of the YouTube Data API v3 video
+// resource this uploader sets. It's a plain struct rather than
+// google.golang.org/api/youtube/v3's types since the upload no longer
+// goes through that client library.
+type videoMetadata struct {
+	Snippet videoSnippet `json:"snippet"`
+	Status  videoStatus  `json:"status"`
+}
+
+type videoSnippet struct {
+	Title                string   `json:"title"`
+	Description          string   `json:"description"`
+	Tags                 []string `json:"tags,omitempty"`
+	CategoryID           string   `json:"categoryId,omitempty"`
+	DefaultLanguage      string   `json:"defaultLanguage,omitempty"`
+	DefaultAudioLanguage string   `json:"defaultAudioLanguage,omitempty"`
+}
+
+type videoStatus struct {
+	PrivacyStatus           string `json:"privacyStatus"`
+	SelfDeclaredMadeForKids bool   `json:"selfDeclaredMadeForKids"`
+	NotifySubscribers       bool   `json:"notifySubscribers"`
+	PublishAt               string `json:"publishAt,omitempty"`
+}
+
+// uploadedVideo is the subset of the API's create response this uploader reads.
+type uploadedVideo struct {
+	ID string `json:"id"`
+}
+
+// videoLocalizationUpdate is the videos.update body SetLocalization sends
+// to attach a translated title/description to an already-uploaded video.
+type videoLocalizationUpdate struct {
+	ID            string                       `json:"id"`
+	Localizations map[string]videoLocalization `json:"localizations"`
+}
+
+type videoLocalization struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// resumeCheckpoint is persisted to logs/upload_resume_<runID>.json after
+// every successful chunk, so a re-invocation of Run for the same runID
+// resumes from the last confirmed offset instead of restarting.
+type resumeCheckpoint struct {
+	UploadURL string `json:"upload_url"`
+	VideoFile string `json:"video_file"`
+	BytesSent int64  `json:"bytes_sent"`
+	// SHA256 is sha256File's hash of the full VideoFile at the time this
+	// checkpoint was written — Run recomputes it on every call and
+	// resumeOrStart refuses to resume unless it still matches, so a file
+	// that changed since (re-render, different take) can't silently
+	// resume a chunked upload against the wrong content.
+	SHA256 string `json:"sha256"`
+}
+
+// Run uploads videoFile to YouTube with metadata, using the resumable
+// upload protocol in chunkSize pieces so it survives a network blip or a
+// process restart partway through a large file.
+func (u *Uploader) Run(ctx context.Context, videoFile string, metadata *types.VideoMetadata) (string, string, error) {
+	log.Println("[upload] Authenticating with YouTube API...")
+	client, err := u.oauthClient(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("youtube auth: %w", err)
+	}
+
+	f, err := os.Open(videoFile)
+	if err != nil {
+		return "", "", fmt.Errorf("open video file: %w", err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return "", "", fmt.Errorf("stat video file: %w", err)
+	}
+	total := fi.Size()
+	log.Printf("[upload] File size: %.1f MB", float64(total)/1024/1024)
+
+	// Hash videoFile unconditionally, every run, and persist that hash in
+	// every checkpoint — a resume is only trusted when this matches the
+	// checkpoint's recorded hash (see resumeOrStart), so a file that
+	// changed between runs (re-render, different take) can't silently
+	// resume a chunked upload against the wrong content.
+	log.Println("[upload] Hashing video file...")
+	sum, err := sha256File(f)
+	if err != nil {
+		return "", "", fmt.Errorf("hash video file: %w", err)
+	}
+
+	checkpointPath := u.checkpointPath()
+	uploadURL, sent, done, err := u.resumeOrStart(ctx, client, checkpointPath, videoFile, sum, total, metadata)
+	if err != nil {
+		return "", "", err
+	}
+
+	videoID := ""
+	if done != nil {
+		videoID = done.ID
+	} else {
+		videoID, err = u.uploadChunks(ctx, client, checkpointPath, uploadURL, f, videoFile, sum, sent, total)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	videoURL := fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID)
+	log.Printf("[upload] ✅ Uploaded successfully!")
+	log.Printf("[upload] Video ID: %s", videoID)
+	log.Printf("[upload] Video URL: %s", videoURL)
+
+	_ = os.Remove(checkpointPath) // done — don't let a finished upload look resumable next run
+
+	return videoID, videoURL, nil
+}
+
+// SetLocalization attaches a translated title/description for language to
+// videoID's Localizations map via videos.update. Each localized variant
+// still uploads as its own video (it has its own audio track), so this is
+// purely an extra metadata nicety on the primary/master upload for
+// YouTube's own title/description localization switching.
+func (u *Uploader) SetLocalization(ctx context.Context, videoID, language string, metadata *types.VideoMetadata) error {
+	client, err := u.oauthClient(ctx)
+	if err != nil {
+		return fmt.Errorf("youtube auth: %w", err)
+	}
+
+	body := videoLocalizationUpdate{
+		ID: videoID,
+		Localizations: map[string]videoLocalization{
+			language: {Title: metadata.Title, Description: metadata.Description},
+		},
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal localization update: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, videosUpdateEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("update localizations: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("update localizations: %s: %s", resp.Status, readBody(resp))
+	}
+
+	log.Printf("[upload] Attached %q localization to video %s", language, videoID)
+	return nil
+}
+
+// SetThumbnail uploads the JPEG at imagePath as videoID's custom thumbnail
+// via thumbnails.set. Modeled on SetLocalization but posts raw image bytes
+// instead of a JSON body, per the API's own convention for this endpoint.
+func (u *Uploader) SetThumbnail(ctx context.Context, videoID, imagePath string) error {
+	client, err := u.oauthClient(ctx)
+	if err != nil {
+		return fmt.Errorf("youtube auth: %w", err)
+	}
+
+	data, err := os.ReadFile(imagePath)
+	if err != nil {
+		return fmt.Errorf("read thumbnail: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s?videoId=%s", thumbnailsSetEndpoint, videoID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "image/jpeg")
+	req.ContentLength = int64(len(data))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("set thumbnail: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("set thumbnail: %s: %s", resp.Status, readBody(resp))
+	}
+
+	log.Printf("[upload] Set custom thumbnail for video %s", videoID)
+	return nil
+}
+
+// resumeOrStart picks up checkpointPath's upload session if it names
+// videoFile and videoFile's current sum still matches the checkpoint's
+// recorded SHA256 (Run hashes videoFile unconditionally every call, so
+// this is never vacuously true); otherwise it starts a fresh resumable
+// session. done is non-nil in the rare case the prior session had
+// actually already completed server-side before the checkpoint could be
+// cleared (e.g. killed right after the final chunk's response).
+func (u *Uploader) resumeOrStart(ctx context.Context, client *http.Client, checkpointPath, videoFile, sum string, total int64, metadata *types.VideoMetadata) (uploadURL string, sent int64, done *uploadedVideo, err error) {
+	if cp, ok := loadCheckpoint(checkpointPath); ok && cp.VideoFile == videoFile {
+		if cp.SHA256 != sum {
+			log.Printf("[upload] Checkpoint's recorded hash doesn't match %s's current content — starting a fresh upload instead of resuming", videoFile)
+		} else {
+			log.Printf("[upload] Found resume checkpoint at byte %d of %d — confirming with YouTube...", cp.BytesSent, total)
+			if sent, done, err := u.queryOffset(ctx, client, cp.UploadURL, total); err == nil {
+				return cp.UploadURL, sent, done, nil
+			} else {
+				log.Printf("[upload] Resume check failed: %v — starting a fresh upload", err)
+			}
+		}
+	}
+
+	uploadURL, err = u.initiateUpload(ctx, client, metadata, total)
+	return uploadURL, 0, nil, err
+}
+
+// initiateUpload issues the initial POST to open a resumable upload
+// session and returns the per-session upload URL from its Location header.
+func (u *Uploader) initiateUpload(ctx context.Context, client *http.Client, metadata *types.VideoMetadata, total int64) (string, error) {
+	language := metadata.Language
+	if language == "" {
+		language = u.cfg.Upload.DefaultLanguage
+	}
+	body := videoMetadata{
+		Snippet: videoSnippet{
+			Title:                metadata.Title,
+			Description:          metadata.Description,
+			Tags:                 metadata.Tags,
+			CategoryID:           metadata.CategoryID,
+			DefaultLanguage:      language,
+			DefaultAudioLanguage: language,
+		},
+		Status: videoStatus{
+			PrivacyStatus:           metadata.Visibility,
+			SelfDeclaredMadeForKids: u.cfg.Upload.MadeForKids,
+			NotifySubscribers:       u.cfg.Upload.NotifySubscribers,
+		},
+	}
+	if metadata.ScheduledTimeUTC != "" && metadata.Visibility == "public" {
+		body.Status.PrivacyStatus = "private" // must be private to schedule
+		body.Status.PublishAt = metadata.ScheduledTimeUTC
+		log.Printf("[upload] Scheduled for: %s UTC", metadata.ScheduledTimeUTC)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("marshal video metadata: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, resumableUploadEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Type", "video/*")
+	req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(total, 10))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("initiate resumable upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("initiate resumable upload: %s: %s", resp.Status, readBody(resp))
+	}
+
+	uploadURL := resp.Header.Get("Location")
+	if uploadURL == "" {
+		return "", fmt.Errorf("initiate resumable upload: response had no Location header")
+	}
+	return uploadURL, nil
+}
+
+// queryOffset asks an existing resumable upload session how many bytes it
+// has received so far, per the protocol's status-check convention: an
+// empty PUT with Content-Range: bytes */total draws a 308 naming the
+// confirmed range, or a 200/201 if the upload had actually already
+// finished server-side.
+func (u *Uploader) queryOffset(ctx context.Context, client *http.Client, uploadURL string, total int64) (int64, *uploadedVideo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.ContentLength = 0
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", total))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		var v uploadedVideo
+		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+			return 0, nil, fmt.Errorf("decode completed-upload response: %w", err)
+		}
+		return total, &v, nil
+	case 308:
+		confirmed, ok, err := parseRangeEnd(resp.Header.Get("Range"))
+		if err != nil {
+			return 0, nil, err
+		}
+		if !ok {
+			return 0, nil, nil // session exists but has nothing confirmed yet
+		}
+		return confirmed, nil, nil
+	default:
+		return 0, nil, fmt.Errorf("query upload offset: %s: %s", resp.Status, readBody(resp))
+	}
+}
+
+// parseRangeEnd extracts the exclusive end offset from a resumable-upload
+// "Range: bytes=0-8388607" response header. ok is false for an empty header
+// (session exists but the server has nothing confirmed yet).
+func parseRangeEnd(rng string) (end int64, ok bool, err error) {
+	if rng == "" {
+		return 0, false, nil
+	}
+	_, endStr, cut := strings.Cut(strings.TrimPrefix(rng, "bytes="), "-")
+	if !cut {
+		return 0, false, fmt.Errorf("unrecognized Range header %q", rng)
+	}
+	last, err := strconv.ParseInt(endStr, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("parse Range header %q: %w", rng, err)
+	}
+	return last + 1, true, nil
+}
+
+// uploadChunks PUTs videoFile in u.chunkSize pieces starting at sent,
+// checkpointing to checkpointPath after each confirmed chunk, until the
+// final PUT's 200/201 response carries the created video's ID.
+func (u *Uploader) uploadChunks(ctx context.Context, client *http.Client, checkpointPath, uploadURL string, f *os.File, videoFile, sum string, sent, total int64) (string, error) {
+	chunk := make([]byte, u.chunkSize)
+	for sent < total {
+		end := sent + u.chunkSize
+		if end > total {
+			end = total
+		}
+
+		if _, err := f.Seek(sent, io.SeekStart); err != nil {
+			return "", fmt.Errorf("seek to offset %d: %w", sent, err)
+		}
+		buf := chunk[:end-sent]
+		if _, err := io.ReadFull(f, buf); err != nil {
+			return "", fmt.Errorf("read chunk at offset %d: %w", sent, err)
+		}
+
+		videoID, confirmed, err := u.putChunkWithRetry(ctx, client, uploadURL, buf, sent, end, total)
+		if err != nil {
+			return "", err
+		}
+		sent = confirmed
+
+		if err := saveCheckpoint(checkpointPath, resumeCheckpoint{UploadURL: uploadURL, VideoFile: videoFile, BytesSent: sent, SHA256: sum}); err != nil {
+			log.Printf("[upload] Warning: save resume checkpoint failed: %v", err)
+		}
+
+		log.Printf("[upload] %d of %d bytes (%.1f%%)", sent, total, float64(sent)/float64(total)*100)
+		if u.ProgressCallback != nil {
+			u.ProgressCallback(sent, total)
+		}
+
+		if videoID != "" {
+			return videoID, nil
+		}
+	}
+	return "", fmt.Errorf("upload loop exited at byte %d of %d without a video ID", sent, total)
+}
+
+// putChunkWithRetry retries a single chunk PUT with exponential backoff on
+// 5xx responses and network errors, but returns immediately on a 4xx
+// (bad/expired auth, malformed request) since retrying those just wastes
+// the chunk's bandwidth again for the same failure.
+func (u *Uploader) putChunkWithRetry(ctx context.Context, client *http.Client, uploadURL string, chunk []byte, start, end, total int64) (videoID string, confirmed int64, err error) {
+	backoff := time.Second
+	var lastErr error
+	for attempt := 1; attempt <= maxChunkRetries; attempt++ {
+		videoID, confirmed, retryable, err := u.putChunk(ctx, client, uploadURL, chunk, start, end, total)
+		if err == nil {
+			return videoID, confirmed, nil
+		}
+		if !retryable {
+			return "", 0, err
+		}
+
+		lastErr = err
+		log.Printf("[upload] chunk %d-%d failed (attempt %d/%d): %v — retrying in %s", start, end-1, attempt, maxChunkRetries, err, backoff)
+		select {
+		case <-ctx.Done():
+			return "", 0, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return "", 0, fmt.Errorf("chunk %d-%d: exhausted %d retries: %w", start, end-1, maxChunkRetries, lastErr)
+}
+
+// putChunk makes one PUT attempt for chunk[start:end) of a total-byte
+// upload. confirmed is the server's actually-acknowledged exclusive end
+// offset — taken from the 308 response's Range header rather than assumed
+// to be end, since a server can ack less than it was sent. retryable
+// distinguishes a network/5xx failure worth retrying from a 4xx that won't
+// improve on retry.
+func (u *Uploader) putChunk(ctx context.Context, client *http.Client, uploadURL string, chunk []byte, start, end, total int64) (videoID string, confirmed int64, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(chunk))
+	if err != nil {
+		return "", 0, false, err
+	}
+	req.ContentLength = int64(len(chunk))
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, total))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, true, fmt.Errorf("PUT chunk: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == 308:
+		rangeEnd, ok, parseErr := parseRangeEnd(resp.Header.Get("Range"))
+		if parseErr != nil {
+			return "", 0, false, parseErr
+		}
+		if !ok {
+			return "", start, false, nil // 308 with no Range header: server confirmed nothing new
+		}
+		return "", rangeEnd, false, nil
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated:
+		var v uploadedVideo
+		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+			return "", 0, false, fmt.Errorf("decode final upload response: %w", err)
+		}
+		return v.ID, end, false, nil
+	case resp.StatusCode >= 500:
+		return "", 0, true, fmt.Errorf("chunk PUT: %s: %s", resp.Status, readBody(resp))
+	default:
+		return "", 0, false, fmt.Errorf("chunk PUT: %s: %s", resp.Status, readBody(resp))
+	}
+}
+
+// readBody returns a truncated, whitespace-trimmed response body for
+// error messages, without risking an unbounded read of a misbehaving
+// response.
+func readBody(resp *http.Response) string {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+	return strings.TrimSpace(string(data))
+}
+
+// sha256File hashes f's full contents, leaving the file positioned at the
+// start for the caller.
+func sha256File(f *os.File) (string, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// checkpointPath returns where this run's resume checkpoint is persisted.
+func (u *Uploader) checkpointPath() string {
+	return filepath.Join(u.cfg.Paths.Logs, fmt.Sprintf("upload_resume_%s.json", u.runID))
+}
+
+func loadCheckpoint(path string) (resumeCheckpoint, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return resumeCheckpoint{}, false
+	}
+	var cp resumeCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return resumeCheckpoint{}, false
+	}
+	return cp, true
+}
+
+func saveCheckpoint(path string, cp resumeCheckpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// oauthClient builds an OAuth2 HTTP client from env credentials, good for
+// both the youtube/v3 metadata calls and the raw resumable-upload PUTs.
+func (u *Uploader) oauthClient(ctx context.Context) (*http.Client, error) {
+	clientID := os.Getenv("YOUTUBE_CLIENT_ID")
+	clientSecret := os.Getenv("YOUTUBE_CLIENT_SECRET")
+	refreshToken := os.Getenv("YOUTUBE_REFRESH_TOKEN")
+
+	if clientID == "" || clientSecret == "" || refreshToken == "" {
+		return nil, fmt.Errorf("YOUTUBE_CLIENT_ID, YOUTUBE_CLIENT_SECRET, or YOUTUBE_REFRESH_TOKEN not set")
+	}
+
+	conf := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint:     google.Endpoint,
+		Scopes:       []string{youtubeUploadScope, youtubeScope},
+	}
+
+	token := &oauth2.Token{
+		RefreshToken: refreshToken,
+		Expiry:       time.Now().Add(-time.Hour), // force refresh
+	}
+
+	return oauth2.NewClient(ctx, conf.TokenSource(ctx, token)), nil
+}
+
+// LogUpload saves the upload result to the logs directory
+func LogUpload(videoID, videoURL, videoFile, outputDir string, metadata *types.VideoMetadata) error {
+	logEntry := map[string]interface{}{
+		"video_id":      videoID,
+		"video_url":     videoURL,
+		"title":         metadata.Title,
+		"scheduled_utc": metadata.ScheduledTimeUTC,
+		"uploaded_at":   time.Now().UTC().Format(time.RFC3339),
+		"video_file":    videoFile,
+	}
+
+	logFile := fmt.Sprintf("%s/upload_%s.json", outputDir, time.Now().Format("20060102_150405"))
+	data, _ := json.MarshalIndent(logEntry, "", "  ")
+	if err := os.WriteFile(logFile, data, 0644); err != nil {
+		return err
+	}
+
+	log.Printf("[upload] Upload log saved: %s", logFile)
+	return nil
+}
@@ -0,0 +1,35 @@
+package upload
+
+import "testing"
+
+func TestParseRangeEndEmptyHeader(t *testing.T) {
+	end, ok, err := parseRangeEnd("")
+	if err != nil {
+		t.Fatalf("parseRangeEnd: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected ok=false for an empty header, got end=%d", end)
+	}
+}
+
+func TestParseRangeEndConfirmedRange(t *testing.T) {
+	end, ok, err := parseRangeEnd("bytes=0-8388607")
+	if err != nil {
+		t.Fatalf("parseRangeEnd: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected ok=true")
+	}
+	if end != 8388608 {
+		t.Fatalf("end = %d, want 8388608 (exclusive, one past the last confirmed byte)", end)
+	}
+}
+
+func TestParseRangeEndMalformed(t *testing.T) {
+	if _, _, err := parseRangeEnd("bytes=garbage"); err == nil {
+		t.Fatalf("expected an error for a malformed Range header")
+	}
+	if _, _, err := parseRangeEnd("nope"); err == nil {
+		t.Fatalf("expected an error for a header missing the bytes= prefix and a dash")
+	}
+}
@@ -0,0 +1,188 @@
+package assetcache
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+// encodeBlurhash implements the public blurhash algorithm (see
+// github.com/woltapp/blurhash) directly against image/color rather than
+// importing github.com/buckket/go-blurhash, since this string format is
+// small and stable enough that hand-rolling it avoids a dependency for one
+// call site. xComponents/yComponents must each be in [1,9].
+func encodeBlurhash(img image.Image, xComponents, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", fmt.Errorf("blurhash: components must be in [1,9], got %dx%d", xComponents, yComponents)
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return "", fmt.Errorf("blurhash: empty image")
+	}
+
+	factors := make([][3]float64, xComponents*yComponents)
+	for y := 0; y < yComponents; y++ {
+		for x := 0; x < xComponents; x++ {
+			factors[y*xComponents+x] = multiplyBasisFunction(img, bounds, x, y)
+		}
+	}
+
+	dc := factors[0]
+	ac := factors[1:]
+
+	hash := ""
+	sizeFlag := (xComponents - 1) + (yComponents-1)*9
+	hash += encode83(sizeFlag, 1)
+
+	var maximumValue float64
+	if len(ac) > 0 {
+		var actualMax float64
+		for _, f := range ac {
+			for _, c := range f {
+				if c > actualMax {
+					actualMax = c
+				}
+				if -c > actualMax {
+					actualMax = -c
+				}
+			}
+		}
+		quantizedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maximumValue = float64(quantizedMax+1) / 166
+		hash += encode83(quantizedMax, 1)
+	} else {
+		maximumValue = 1
+		hash += encode83(0, 1)
+	}
+
+	hash += encode83(encodeDC(dc), 4)
+	for _, f := range ac {
+		hash += encode83(encodeAC(f, maximumValue), 2)
+	}
+	return hash, nil
+}
+
+// multiplyBasisFunction returns the average sRGB-linear color of img,
+// weighted by the (i,j)th 2D DCT basis function — the core of blurhash's
+// "a handful of cosine terms describe this image's rough shape and color"
+// compression.
+func multiplyBasisFunction(img image.Image, bounds image.Rectangle, i, j int) [3]float64 {
+	var r, g, b float64
+	width, height := bounds.Dx(), bounds.Dy()
+	normalization := 1.0
+	if i != 0 || j != 0 {
+		normalization = 2.0
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			basis := math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+				math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+			cr, cg, cb, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			r += basis * srgbToLinear(int(cr>>8))
+			g += basis * srgbToLinear(int(cg>>8))
+			b += basis * srgbToLinear(int(cb>>8))
+		}
+	}
+
+	scale := normalization / float64(width*height)
+	return [3]float64{r * scale, g * scale, b * scale}
+}
+
+func encodeDC(value [3]float64) int {
+	r := linearToSrgb(value[0])
+	g := linearToSrgb(value[1])
+	b := linearToSrgb(value[2])
+	return (r << 16) + (g << 8) + b
+}
+
+func encodeAC(value [3]float64, maximumValue float64) int {
+	quantize := func(v float64) int {
+		q := int(math.Floor(signPow(v/maximumValue, 0.5)*9 + 9.5))
+		if q < 0 {
+			q = 0
+		}
+		if q > 18 {
+			q = 18
+		}
+		return q
+	}
+	return quantize(value[0])*19*19 + quantize(value[1])*19 + quantize(value[2])
+}
+
+func signPow(v, exp float64) float64 {
+	if v < 0 {
+		return -math.Pow(-v, exp)
+	}
+	return math.Pow(v, exp)
+}
+
+// srgbToLinear converts an 8-bit sRGB channel value to linear light, per
+// the sRGB transfer function blurhash's spec calls for.
+func srgbToLinear(v int) float64 {
+	x := float64(v) / 255
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+// linearToSrgb is srgbToLinear's inverse, returning an 8-bit channel value.
+func linearToSrgb(v float64) int {
+	v = math.Max(0, math.Min(1, v))
+	var x float64
+	if v <= 0.0031308 {
+		x = v * 12.92
+	} else {
+		x = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	result := int(math.Round(x * 255))
+	if result < 0 {
+		result = 0
+	}
+	if result > 255 {
+		result = 255
+	}
+	return result
+}
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// encode83 encodes value in base83 (the alphabet blurhash's spec uses, to
+// keep the string JSON/URL-safe) as a fixed-width, zero-padded string of
+// length digits.
+func encode83(value, length int) string {
+	buf := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		digit := value % 83
+		buf[i] = base83Chars[digit]
+		value /= 83
+	}
+	return string(buf)
+}
+
+func decode83(s string) int {
+	value := 0
+	for _, c := range s {
+		value = value*83 + strings.IndexRune(base83Chars, c)
+	}
+	return value
+}
+
+// BlurhashAverageColorHex decodes hash's DC term — the average color every
+// blurhash string leads with — into a "0xRRGGBB" string usable directly as
+// an ffmpeg color= value, without decoding the full placeholder image.
+// Returns ok=false for an empty or malformed hash.
+func BlurhashAverageColorHex(hash string) (string, bool) {
+	if len(hash) < 6 {
+		return "", false
+	}
+	dc := decode83(hash[2:6])
+	r := (dc >> 16) & 0xff
+	g := (dc >> 8) & 0xff
+	b := dc & 0xff
+	return fmt.Sprintf("0x%02x%02x%02x", r, g, b), true
+}
@@ -0,0 +1,150 @@
+package assetcache
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"testing"
+)
+
+func TestEncode83Decode83RoundTrip(t *testing.T) {
+	for _, v := range []int{0, 1, 82, 83, 6888, 1 << 20} {
+		length := 4
+		encoded := encode83(v, length)
+		if len(encoded) != length {
+			t.Fatalf("encode83(%d, %d) produced length %d", v, length, len(encoded))
+		}
+		if got := decode83(encoded); got != v {
+			t.Errorf("decode83(encode83(%d)) = %d, want %d", v, got, v)
+		}
+	}
+}
+
+func TestSrgbLinearRoundTrip(t *testing.T) {
+	for _, v := range []int{0, 1, 64, 128, 200, 255} {
+		linear := srgbToLinear(v)
+		back := linearToSrgb(linear)
+		if back != v {
+			t.Errorf("linearToSrgb(srgbToLinear(%d)) = %d, want %d", v, back, v)
+		}
+	}
+}
+
+func TestLinearToSrgbClampsOutOfRange(t *testing.T) {
+	if got := linearToSrgb(-1); got != 0 {
+		t.Errorf("linearToSrgb(-1) = %d, want 0", got)
+	}
+	if got := linearToSrgb(2); got != 255 {
+		t.Errorf("linearToSrgb(2) = %d, want 255", got)
+	}
+}
+
+func TestEncodeBlurhashRejectsInvalidComponents(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	if _, err := encodeBlurhash(img, 0, 3); err == nil {
+		t.Error("expected an error for xComponents out of [1,9]")
+	}
+	if _, err := encodeBlurhash(img, 3, 10); err == nil {
+		t.Error("expected an error for yComponents out of [1,9]")
+	}
+}
+
+func TestEncodeBlurhashRejectsEmptyImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 0, 0))
+	if _, err := encodeBlurhash(img, 4, 3); err == nil {
+		t.Error("expected an error for an empty image")
+	}
+}
+
+func solidImage(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestEncodeBlurhashProducesExpectedLengthAndAlphabet(t *testing.T) {
+	img := solidImage(8, 8, color.RGBA{R: 120, G: 80, B: 200, A: 255})
+
+	const xComp, yComp = 4, 3
+	hash, err := encodeBlurhash(img, xComp, yComp)
+	if err != nil {
+		t.Fatalf("encodeBlurhash: %v", err)
+	}
+
+	// 1 (size flag) + 1 (max AC value) + 4 (DC) + 2 per remaining AC term.
+	wantLen := 1 + 1 + 4 + 2*(xComp*yComp-1)
+	if len(hash) != wantLen {
+		t.Fatalf("hash length = %d, want %d (hash: %q)", len(hash), wantLen, hash)
+	}
+	for _, c := range hash {
+		found := false
+		for _, a := range base83Chars {
+			if a == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("hash %q contains non-base83 character %q", hash, c)
+		}
+	}
+}
+
+func TestEncodeBlurhashDCApproximatesAverageColor(t *testing.T) {
+	img := solidImage(6, 6, color.RGBA{R: 200, G: 40, B: 10, A: 255})
+
+	hash, err := encodeBlurhash(img, 3, 3)
+	if err != nil {
+		t.Fatalf("encodeBlurhash: %v", err)
+	}
+	hex, ok := BlurhashAverageColorHex(hash)
+	if !ok {
+		t.Fatalf("BlurhashAverageColorHex(%q) reported ok=false", hash)
+	}
+
+	r, g, b := mustParseHex(t, hex)
+	// A solid-color image's DC term should decode back close to that same
+	// color (within sRGB<->linear quantization error), not exactly equal.
+	assertNear(t, "R", r, 200, 10)
+	assertNear(t, "G", g, 40, 10)
+	assertNear(t, "B", b, 10, 10)
+}
+
+func TestBlurhashAverageColorHexRejectsMalformed(t *testing.T) {
+	if _, ok := BlurhashAverageColorHex(""); ok {
+		t.Error("expected ok=false for an empty hash")
+	}
+	if _, ok := BlurhashAverageColorHex("ab"); ok {
+		t.Error("expected ok=false for a too-short hash")
+	}
+}
+
+func mustParseHex(t *testing.T, hex string) (r, g, b int) {
+	t.Helper()
+	if len(hex) != 8 || hex[:2] != "0x" {
+		t.Fatalf("malformed hex color %q", hex)
+	}
+	parse := func(s string) int {
+		v, err := strconv.ParseInt(s, 16, 32)
+		if err != nil {
+			t.Fatalf("parse hex channel %q: %v", s, err)
+		}
+		return int(v)
+	}
+	return parse(hex[2:4]), parse(hex[4:6]), parse(hex[6:8])
+}
+
+func assertNear(t *testing.T, label string, got, want, tolerance int) {
+	t.Helper()
+	diff := got - want
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > tolerance {
+		t.Errorf("%s channel = %d, want within %d of %d", label, got, tolerance, want)
+	}
+}
@@ -0,0 +1,479 @@
+// Package assetcache is an on-disk, content-addressed cache for downloaded
+// image assets (proof photos, thumbnail backgrounds): bytes are hashed with
+// SHA-256 so the same image reached via two different URLs (a common
+// occurrence across visuals.ProofScraper's fanned-out sources) is only ever
+// stored once, and each entry's sidecar JSON carries enough metadata
+// (dimensions, MIME, a blurhash placeholder) for downstream rendering to use
+// even before — or instead of — the full image.
+package assetcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"true-crime-pipeline/config"
+	"true-crime-pipeline/useragent"
+)
+
+// Asset is one cached image: SourceURL is whichever URL first produced
+// these bytes (a later Fetch of a different URL with identical content
+// reuses Path without re-downloading, but SourceURL keeps recording the
+// first one).
+type Asset struct {
+	Hash      string
+	Path      string
+	MIME      string
+	Width     int
+	Height    int
+	Blurhash  string
+	SourceURL string
+	SizeBytes int64
+}
+
+// assetMeta is the sidecar JSON stored next to each cached asset file.
+type assetMeta struct {
+	Ext        string    `json:"ext"`
+	MIME       string    `json:"mime"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Blurhash   string    `json:"blurhash"`
+	SourceURL  string    `json:"source_url"`
+	SizeBytes  int64     `json:"size_bytes"`
+	FetchedAt  time.Time `json:"fetched_at"`
+	LastAccess time.Time `json:"last_access"`
+}
+
+// Store is a content-addressed on-disk asset cache rooted at Dir. Entries
+// live at Dir/<first two hex chars>/<hash><ext> with a Dir/<hh>/<hash>.json
+// sidecar; a Dir/urls/<hh>/<urlhash>.json index lets Fetch short-circuit a
+// previously-seen URL without re-reading its content hash off disk twice.
+type Store struct {
+	dir          string
+	httpClient   *http.Client
+	maxSizeBytes int64
+	uaPool       *useragent.Pool
+
+	mu sync.Mutex
+}
+
+// NewStore builds a Store from cfg. httpClient may be nil, in which case
+// Fetch uses its own client with a 20s timeout. uaPool may be nil, in
+// which case download builds its own pool from a zero-value
+// config.UserAgentConfig.
+func NewStore(cfg config.AssetCacheConfig, httpClient *http.Client, uaPool *useragent.Pool) *Store {
+	dir := cfg.Dir
+	if dir == "" {
+		dir = "asset_cache"
+	}
+	maxSizeMiB := cfg.MaxSizeMiB
+	if maxSizeMiB <= 0 {
+		maxSizeMiB = 1024
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 20 * time.Second}
+	}
+	if uaPool == nil {
+		uaPool = useragent.NewPool(config.UserAgentConfig{}, httpClient)
+	}
+	return &Store{
+		dir:          dir,
+		httpClient:   httpClient,
+		maxSizeBytes: int64(maxSizeMiB) * 1024 * 1024,
+		uaPool:       uaPool,
+	}
+}
+
+// Fetch downloads rawURL, short-circuiting on disk when either rawURL
+// itself or its content (by SHA-256) has already been cached. The returned
+// Asset's Blurhash is a best-effort placeholder — left empty if the image
+// can't be decoded — so a caller never has to treat it as required.
+func (s *Store) Fetch(ctx context.Context, rawURL string) (*Asset, error) {
+	if asset, ok := s.Lookup(rawURL); ok {
+		return asset, nil
+	}
+
+	// The network round-trip runs without holding s.mu, so concurrent
+	// Fetches for different URLs (the common case — ProofScraper's ranked
+	// candidates are downloaded one at a time, but nothing stops a future
+	// caller fetching several at once) don't serialize behind each other.
+	data, headerMIME, err := s.download(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return s.persist(rawURL, headerMIME, data)
+}
+
+// StoreBytes caches data under rawURL the same way Fetch would, for a
+// caller that already has the bytes in hand rather than a URL to GET —
+// e.g. webdriver.Renderer's extracted in-page image, for a page whose
+// plain HTTP response turned out to be an HTML shell rather than the
+// image itself.
+func (s *Store) StoreBytes(rawURL, mime string, data []byte) (*Asset, error) {
+	return s.persist(rawURL, mime, data)
+}
+
+// Lookup reports whether rawURL is already cached, without falling back to
+// a network GET (unlike Fetch) or decoding/hashing any bytes (unlike
+// StoreBytes) — a cheap cache-only check for a caller deciding whether it's
+// worth doing the expensive work to get rawURL's bytes at all.
+func (s *Store) Lookup(rawURL string) (*Asset, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	contentHash, ok := s.readURLIndex(hashString(rawURL))
+	if !ok {
+		return nil, false
+	}
+	asset, ok := s.load(contentHash)
+	if !ok {
+		return nil, false
+	}
+	s.touch(contentHash)
+	return asset, true
+}
+
+// Alias records rawURL as pointing at asset's existing content hash,
+// without re-hashing or re-decoding its bytes — for a caller that already
+// holds an Asset obtained under a different URL (e.g. a headless render's
+// resolved image URL) and wants a second URL to hit the same cache entry
+// on a later Fetch/Lookup.
+func (s *Store) Alias(rawURL string, asset *Asset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeURLIndex(hashString(rawURL), asset.Hash)
+}
+
+// persist hashes, dedups against any existing entry, and writes data to
+// disk under its content hash, recording rawURL's own URL-index entry
+// alongside it. Its per-pixel blurhash computation runs before s.mu is
+// taken, for the same reason Fetch's download does.
+func (s *Store) persist(rawURL, headerMIME string, data []byte) (*Asset, error) {
+	if len(data) < 1000 {
+		// Reject before anything is written/indexed — a transient small
+		// placeholder/error page served with HTTP 200 must not get
+		// permanently cached as "the" content for this URL.
+		return nil, fmt.Errorf("assetcache: %s too small (%d bytes)", rawURL, len(data))
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	width, height, blurhash := decodeImageInfo(data)
+	urlHash := hashString(rawURL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if asset, ok := s.load(hash); ok {
+		// Same bytes already cached under a different URL — dedup without
+		// rewriting the file, just record this URL's index entry too.
+		s.writeURLIndex(urlHash, hash)
+		s.touch(hash)
+		return asset, nil
+	}
+
+	ext := extFor(headerMIME, rawURL)
+	entryDir := filepath.Join(s.dir, hash[:2])
+	if err := os.MkdirAll(entryDir, 0755); err != nil {
+		return nil, err
+	}
+	assetPath := filepath.Join(entryDir, hash+ext)
+	if err := os.WriteFile(assetPath, data, 0644); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	meta := assetMeta{
+		Ext:        ext,
+		MIME:       headerMIME,
+		Width:      width,
+		Height:     height,
+		Blurhash:   blurhash,
+		SourceURL:  rawURL,
+		SizeBytes:  int64(len(data)),
+		FetchedAt:  now,
+		LastAccess: now,
+	}
+	if err := writeJSON(filepath.Join(entryDir, hash+".json"), meta); err != nil {
+		return nil, err
+	}
+	s.writeURLIndex(urlHash, hash)
+	s.evict()
+
+	return &Asset{
+		Hash: hash, Path: assetPath, MIME: headerMIME, Width: width, Height: height,
+		Blurhash: blurhash, SourceURL: rawURL, SizeBytes: int64(len(data)),
+	}, nil
+}
+
+// download fetches rawURL's bytes behind a rotating User-Agent/headers
+// set from s.uaPool — several proof-image hosts reject requests that look
+// like a bare script, and a single static UA is itself a tell.
+func (s *Store) download(ctx context.Context, rawURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	s.uaPool.Pick(ctx).Apply(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("assetcache: %s returned %d", rawURL, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetBytes))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// maxAssetBytes bounds a single downloaded asset, matching the cap
+// ProofScraper's old downloadFile enforced — a misbehaving source
+// shouldn't be able to buffer an unbounded response into memory.
+const maxAssetBytes = 10 * 1024 * 1024
+
+// load reconstructs an Asset from hash's sidecar JSON, returning ok=false if
+// no such entry exists (a miss, or one already evicted/purged).
+func (s *Store) load(hash string) (*Asset, bool) {
+	entryDir := filepath.Join(s.dir, hash[:2])
+	meta, ok := readJSON(filepath.Join(entryDir, hash+".json"))
+	if !ok {
+		return nil, false
+	}
+	assetPath := filepath.Join(entryDir, hash+meta.Ext)
+	if _, err := os.Stat(assetPath); err != nil {
+		return nil, false
+	}
+	return &Asset{
+		Hash: hash, Path: assetPath, MIME: meta.MIME, Width: meta.Width, Height: meta.Height,
+		Blurhash: meta.Blurhash, SourceURL: meta.SourceURL, SizeBytes: meta.SizeBytes,
+	}, true
+}
+
+// touch refreshes hash's LastAccess so evict's LRU ordering reflects this
+// read, not just the original download.
+func (s *Store) touch(hash string) {
+	path := filepath.Join(s.dir, hash[:2], hash+".json")
+	meta, ok := readJSON(path)
+	if !ok {
+		return
+	}
+	meta.LastAccess = time.Now()
+	_ = writeJSON(path, meta)
+}
+
+func (s *Store) urlIndexPath(urlHash string) string {
+	return filepath.Join(s.dir, "urls", urlHash[:2], urlHash+".json")
+}
+
+func (s *Store) readURLIndex(urlHash string) (string, bool) {
+	data, err := os.ReadFile(s.urlIndexPath(urlHash))
+	if err != nil {
+		return "", false
+	}
+	var entry struct {
+		ContentHash string `json:"content_hash"`
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", false
+	}
+	return entry.ContentHash, entry.ContentHash != ""
+}
+
+func (s *Store) writeURLIndex(urlHash, contentHash string) {
+	path := s.urlIndexPath(urlHash)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	data, err := json.Marshal(struct {
+		ContentHash string `json:"content_hash"`
+	}{ContentHash: contentHash})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
+
+// evict removes the least-recently-accessed entries until the cache is back
+// under maxSizeBytes. It does not clean up dangling URL-index entries
+// pointing at an evicted hash — a later Fetch for that URL just misses the
+// index (load fails) and re-downloads, which is the same cost as a cold
+// cache and far simpler than keeping a reverse index in sync.
+func (s *Store) evict() {
+	type candidate struct {
+		hash       string
+		path       string
+		jsonPath   string
+		size       int64
+		lastAccess time.Time
+	}
+	var candidates []candidate
+	var total int64
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	for _, shard := range entries {
+		if !shard.IsDir() || shard.Name() == "urls" {
+			continue
+		}
+		shardDir := filepath.Join(s.dir, shard.Name())
+		files, err := os.ReadDir(shardDir)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+				continue
+			}
+			hash := strings.TrimSuffix(f.Name(), ".json")
+			jsonPath := filepath.Join(shardDir, f.Name())
+			meta, ok := readJSON(jsonPath)
+			if !ok {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				hash: hash, path: filepath.Join(shardDir, hash+meta.Ext), jsonPath: jsonPath,
+				size: meta.SizeBytes, lastAccess: meta.LastAccess,
+			})
+			total += meta.SizeBytes
+		}
+	}
+
+	if total <= s.maxSizeBytes {
+		return
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastAccess.Before(candidates[j].lastAccess)
+	})
+	for _, c := range candidates {
+		if total <= s.maxSizeBytes {
+			break
+		}
+		_ = os.Remove(c.path)
+		_ = os.Remove(c.jsonPath)
+		total -= c.size
+	}
+}
+
+// Purge removes every entry last accessed more than olderThan ago,
+// regardless of the store's current size — for an operator clearing out a
+// stale backlog run's assets rather than waiting for size-based eviction.
+func (s *Store) Purge(olderThan time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := time.Now().Add(-olderThan)
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, shard := range entries {
+		if !shard.IsDir() || shard.Name() == "urls" {
+			continue
+		}
+		shardDir := filepath.Join(s.dir, shard.Name())
+		files, err := os.ReadDir(shardDir)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+				continue
+			}
+			hash := strings.TrimSuffix(f.Name(), ".json")
+			jsonPath := filepath.Join(shardDir, f.Name())
+			meta, ok := readJSON(jsonPath)
+			if !ok || meta.LastAccess.After(cutoff) {
+				continue
+			}
+			_ = os.Remove(filepath.Join(shardDir, hash+meta.Ext))
+			_ = os.Remove(jsonPath)
+		}
+	}
+	return nil
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// extFor picks a file extension from headerMIME first, falling back to
+// rawURL's own extension, and finally ".jpg" — proof/thumbnail sources
+// overwhelmingly serve jpeg, and every caller here treats the asset as an
+// opaque image file rather than branching on its extension.
+func extFor(headerMIME, rawURL string) string {
+	if headerMIME != "" {
+		if exts, err := mime.ExtensionsByType(headerMIME); err == nil && len(exts) > 0 {
+			return exts[0]
+		}
+	}
+	if u, err := url.Parse(rawURL); err == nil {
+		if ext := filepath.Ext(u.Path); ext != "" {
+			return ext
+		}
+	}
+	return ".jpg"
+}
+
+// decodeImageInfo reads data's dimensions and computes a blurhash
+// placeholder. Both are best-effort: an image Go's stdlib decoders don't
+// recognize (e.g. webp) just gets zero dimensions and an empty blurhash
+// rather than failing the whole Fetch.
+func decodeImageInfo(data []byte) (width, height int, blurhash string) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, ""
+	}
+	bounds := img.Bounds()
+	width, height = bounds.Dx(), bounds.Dy()
+	bh, err := encodeBlurhash(img, 4, 3)
+	if err != nil {
+		return width, height, ""
+	}
+	return width, height, bh
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func readJSON(path string) (assetMeta, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return assetMeta{}, false
+	}
+	var meta assetMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return assetMeta{}, false
+	}
+	return meta, true
+}
@@ -0,0 +1,158 @@
+// Package cache is an on-disk, content-addressed artifact store for
+// pipeline.go's stage runner: each stage's output is keyed by a hash of
+// whatever actually determines it (the prior stage's data plus the relevant
+// config subset), so re-running a pipeline after a later-stage failure can
+// restore an earlier stage's already-computed output instead of paying for
+// another Groq call or TTS/render pass.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Key returns a stable content hash for v — the sha256 of its canonical JSON
+// encoding — used to key a stage's cached output by what actually determines
+// it rather than by run ID or stage name.
+func Key(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("cache: marshal key input: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// manifest records what Put cached, so Restore knows whether to copy back a
+// single file or a directory tree.
+type manifest struct {
+	Stage string `json:"stage"`
+	Dir   bool   `json:"dir"`
+}
+
+// Store is a content-addressed artifact cache rooted at baseDir/cache/<hash>/.
+type Store struct {
+	baseDir string
+}
+
+// NewStore creates a Store rooted at baseDir (typically a run's own runDir,
+// so a resumed run only ever reuses its own prior attempt's artifacts).
+func NewStore(baseDir string) *Store {
+	return &Store{baseDir: filepath.Join(baseDir, "cache")}
+}
+
+func (s *Store) entryDir(hash string) string {
+	return filepath.Join(s.baseDir, hash)
+}
+
+// Has reports whether hash already has a complete cached artifact. A
+// manifest.json is only written after Put finishes copying, so a half-
+// written entry (the process died mid-copy) correctly reports a miss.
+func (s *Store) Has(hash string) bool {
+	_, err := os.Stat(filepath.Join(s.entryDir(hash), "manifest.json"))
+	return err == nil
+}
+
+// Put copies srcPath (a file or a directory) into hash's cache entry and
+// records stage in its manifest for debugging which stage populated it.
+func (s *Store) Put(hash, stage, srcPath string) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+	dir := s.entryDir(hash)
+	// Clear out any half-written entry from a prior Put that crashed before
+	// writing manifest.json (Has reports those as a miss, so a retry lands
+	// here again) — otherwise stale leftover files could survive alongside
+	// the fresh copy and get served back out by a later Restore.
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	artifact := filepath.Join(dir, "artifact")
+	if info.IsDir() {
+		if err := copyDir(srcPath, artifact); err != nil {
+			return fmt.Errorf("cache: copy dir %s: %w", srcPath, err)
+		}
+	} else {
+		if err := copyFile(srcPath, artifact); err != nil {
+			return fmt.Errorf("cache: copy file %s: %w", srcPath, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest{Stage: stage, Dir: info.IsDir()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0644)
+}
+
+// Restore copies hash's cached artifact back out to destPath, overwriting
+// whatever (if anything) is already there.
+func (s *Store) Restore(hash, destPath string) error {
+	dir := s.entryDir(hash)
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return err
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	artifact := filepath.Join(dir, "artifact")
+	if m.Dir {
+		if err := os.RemoveAll(destPath); err != nil {
+			return err
+		}
+		return copyDir(artifact, destPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return err
+	}
+	return copyFile(artifact, destPath)
+}
+
+func copyFile(src, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func copyDir(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return copyFile(path, target)
+	})
+}
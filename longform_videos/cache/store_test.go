@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyStableForEqualValues(t *testing.T) {
+	a, err := Key(map[string]interface{}{"stage": "script", "n": 3})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	b, err := Key(map[string]interface{}{"stage": "script", "n": 3})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	if a != b {
+		t.Fatalf("Key not stable for equal inputs: %q != %q", a, b)
+	}
+	if len(a) != 64 {
+		t.Fatalf("Key should be a hex-encoded sha256 (64 chars), got %d: %q", len(a), a)
+	}
+}
+
+func TestKeyDiffersForDifferentValues(t *testing.T) {
+	a, err := Key(map[string]interface{}{"n": 1})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	b, err := Key(map[string]interface{}{"n": 2})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	if a == b {
+		t.Fatalf("Key should differ for different inputs, both got %q", a)
+	}
+}
+
+func TestStorePutHasRestoreFile(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	hash, err := Key("some stage input")
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	if store.Has(hash) {
+		t.Fatalf("Has reported a hit before any Put")
+	}
+
+	src := filepath.Join(dir, "src.txt")
+	if err := os.WriteFile(src, []byte("artifact contents"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := store.Put(hash, "script", src); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if !store.Has(hash) {
+		t.Fatalf("Has reported a miss after Put")
+	}
+
+	dest := filepath.Join(dir, "restored.txt")
+	if err := store.Restore(hash, dest); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != "artifact contents" {
+		t.Fatalf("Restore produced %q, want %q", got, "artifact contents")
+	}
+}
+
+func TestStorePutHasRestoreDir(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	srcDir := filepath.Join(dir, "srcdir")
+	if err := os.MkdirAll(filepath.Join(srcDir, "nested"), 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "nested", "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	hash, err := Key("dir stage input")
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	if err := store.Put(hash, "visuals", srcDir); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if !store.Has(hash) {
+		t.Fatalf("Has reported a miss after Put of a directory")
+	}
+
+	destDir := filepath.Join(dir, "restoreddir")
+	if err := store.Restore(hash, destDir); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(destDir, "nested", "a.txt"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != "a" {
+		t.Fatalf("Restore produced %q, want %q", got, "a")
+	}
+}
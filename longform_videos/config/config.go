@@ -0,0 +1,569 @@
+package config
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Research     ResearchConfig     `yaml:"research"`
+	Script       ScriptConfig       `yaml:"script"`
+	Audio        AudioConfig        `yaml:"audio"`
+	Visuals      VisualsConfig      `yaml:"visuals"`
+	Assets       AssetsConfig       `yaml:"assets"`
+	Subtitles    SubtitlesConfig    `yaml:"subtitles"`
+	SFX          SFXConfig          `yaml:"sfx"`
+	Music        MusicConfig        `yaml:"music"`
+	Metadata     MetadataConfig     `yaml:"metadata"`
+	Upload       UploadConfig       `yaml:"upload"`
+	Schedule     ScheduleConfig     `yaml:"schedule"`
+	Paths        PathsConfig        `yaml:"paths"`
+	Cache        CacheConfig        `yaml:"cache"`
+	AssetCache   AssetCacheConfig   `yaml:"asset_cache"`
+	UserAgent    UserAgentConfig    `yaml:"user_agent"`
+	Ingest       IngestConfig       `yaml:"ingest"`
+	Localization LocalizationConfig `yaml:"localization"`
+	Validation   ValidationConfig   `yaml:"validation"`
+}
+
+// ValidationConfig configures media/validate's post-generation checks,
+// which catch a Pollinations error-placeholder image or a truncated TTS
+// clip before it's baked into the final render.
+type ValidationConfig struct {
+	// MaxRetries bounds how many times a failed generator call (TTS,
+	// Pollinations) is retried before a scene falls back to a degraded
+	// placeholder; 0 means 2.
+	MaxRetries int `yaml:"max_retries"`
+	// AudioDurationTolerancePct is how far a scene's synthesized audio may
+	// drift from its word-count-estimated duration before it's rejected as
+	// truncated; 0 means 0.15 (±15%).
+	AudioDurationTolerancePct float64 `yaml:"audio_duration_tolerance_pct"`
+	// FinalDurationToleranceSec is how far the fully assembled video's
+	// total duration may drift from Script.TotalSec before it's rejected
+	// rather than handed to upload.Uploader; 0 means 2.
+	FinalDurationToleranceSec float64 `yaml:"final_duration_tolerance_sec"`
+}
+
+// LocalizationConfig configures additional-language variants: the
+// pipeline generates one extra script/audio/subtitles/metadata/upload per
+// language beyond the primary run, reusing STAGE 4's visual assets
+// scene-for-scene across all of them. Empty means no localization — just
+// the single primary-language run this pipeline has always produced.
+type LocalizationConfig struct {
+	// Languages lists BCP-47 codes (e.g. "es", "pt-BR") to localize into,
+	// in addition to the primary run's (English) language.
+	Languages []string `yaml:"languages"`
+}
+
+// IngestConfig configures visuals/ingest, the yt-dlp-backed B-roll
+// backfill tool driven by the `ingest` pipeline subcommand — it's not part
+// of Assembler's own per-run Run.
+type IngestConfig struct {
+	// ClipMinSec/ClipMaxSec bound each scene-detected sub-clip; 0 means 4/10.
+	ClipMinSec float64 `yaml:"clip_min_sec"`
+	ClipMaxSec float64 `yaml:"clip_max_sec"`
+	// SceneThreshold is the score ffmpeg's select='gt(scene,X)' cuts on;
+	// 0 means 0.4.
+	SceneThreshold float64 `yaml:"scene_threshold"`
+	// Proxies rotates yt-dlp's --proxy flag across these addresses so a big
+	// backfill doesn't concentrate on one IP; empty means no --proxy flag.
+	Proxies []string `yaml:"proxies"`
+	// ProxyCooldownMin benches a proxy that drew an HTTP 429 for this many
+	// minutes before ProxyPool offers it again; 0 means 15.
+	ProxyCooldownMin int `yaml:"proxy_cooldown_min"`
+}
+
+// CacheConfig configures the httpcache package's on-disk HTTP response
+// cache, shared by research.Scraper's Sources and visuals.PollinationsFetcher.
+type CacheConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+	// LifetimeHours is how long a cached entry stays fresh; 0 means 24.
+	LifetimeHours int `yaml:"lifetime_hours"`
+	// MaxSizeMiB is the on-disk budget before the oldest entries (by last
+	// access) are evicted; 0 means 500.
+	MaxSizeMiB int `yaml:"max_size_mib"`
+}
+
+// AssetCacheConfig configures the assetcache package's on-disk,
+// content-addressed store for downloaded proof/thumbnail images, shared by
+// visuals.ProofScraper.
+type AssetCacheConfig struct {
+	// Dir is where sharded asset files and their sidecar JSON live; ""
+	// means "asset_cache".
+	Dir string `yaml:"dir"`
+	// MaxSizeMiB is the on-disk budget before the least-recently-accessed
+	// assets are evicted; 0 means 1024.
+	MaxSizeMiB int `yaml:"max_size_mib"`
+}
+
+// UserAgentConfig configures the useragent package's rotating,
+// browser-share-weighted User-Agent pool, shared by assetcache.Store and
+// the scraped ProofSource backends (Wikipedia, SerpAPI, DuckDuckGo).
+type UserAgentConfig struct {
+	// RefreshHours is how long a fetched caniuse browser-share snapshot
+	// stays fresh before the next Pick re-fetches it; 0 means 24.
+	RefreshHours int `yaml:"refresh_hours"`
+	// Seed makes Pick's weighted-random choice deterministic, for tests;
+	// 0 seeds from the current time instead.
+	Seed int64 `yaml:"seed"`
+}
+
+type ResearchConfig struct {
+	Subreddits        []string `yaml:"subreddits"`
+	NewsKeywords      []string `yaml:"news_keywords"`
+	StoryLookbackDays int      `yaml:"story_lookback_days"`
+	MinRedditScore    int      `yaml:"min_reddit_score"`
+	MinComments       int      `yaml:"min_comments"`
+	MaxStoriesToEval  int      `yaml:"max_stories_to_evaluate"`
+	// Reddit, NewsAPI and RSS gate and configure the research.Source
+	// implementations of the same name. See research.Source.
+	Reddit  RedditSourceConfig  `yaml:"reddit"`
+	NewsAPI NewsAPISourceConfig `yaml:"newsapi"`
+	RSS     RSSSourceConfig     `yaml:"rss"`
+}
+
+// RedditSourceConfig configures the research package's Reddit Source.
+// ClientIDEnv/ClientSecretEnv/UserAgentEnv name the environment variables
+// holding the Reddit app credentials — never the credentials themselves.
+type RedditSourceConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	ClientIDEnv     string `yaml:"client_id_env"`
+	ClientSecretEnv string `yaml:"client_secret_env"`
+	UserAgentEnv    string `yaml:"user_agent_env"`
+}
+
+// NewsAPISourceConfig configures the research package's NewsAPI Source.
+type NewsAPISourceConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	APIKeyEnv string `yaml:"api_key_env"`
+}
+
+// RSSSourceConfig configures the research package's RSS/Atom Source, which
+// needs no credentials. FeedsDir holds one subdirectory per feed — see
+// research.loadFeedInfos — instead of a single hardcoded Google News query,
+// so any RSS2/Atom/JSON Feed URL can be added without a code change.
+type RSSSourceConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	FeedsDir string `yaml:"feeds_dir"`
+}
+
+type ScriptConfig struct {
+	TargetDurationMin int     `yaml:"target_duration_min"`
+	TargetDurationMax int     `yaml:"target_duration_max"`
+	Structure         string  `yaml:"structure"`
+	GroqModel         string  `yaml:"groq_model"`
+	Temperature       float64 `yaml:"temperature"`
+}
+
+type AudioConfig struct {
+	OutputFormat string `yaml:"output_format"`
+	SampleRate   int    `yaml:"sample_rate"`
+	// Provider selects the TTS backend: "edgetts" (default, free fallback),
+	// "elevenlabs", "openai", "azure", "piper", or "exec" (legacy TTS_COMMAND
+	// subprocess). See the providers package.
+	Provider    string `yaml:"provider"`
+	Concurrency int    `yaml:"concurrency"` // max scenes synthesized in parallel; 0 means 1
+	// TargetLUFS is the integrated loudness target (dB LUFS) for the final
+	// mix's loudnorm pass; 0 means -16, YouTube Shorts' target. See
+	// sfx.Matcher.NormalizeLoudness.
+	TargetLUFS float64          `yaml:"target_lufs"`
+	ElevenLabs ElevenLabsConfig `yaml:"elevenlabs"`
+	OpenAI     OpenAIConfig     `yaml:"openai"`
+	Azure      AzureConfig      `yaml:"azure"`
+	Piper      PiperConfig      `yaml:"piper"`
+}
+
+// ElevenLabsConfig configures the providers/elevenlabs backend. APIKeyEnv
+// names the environment variable holding the API key (never the key itself).
+type ElevenLabsConfig struct {
+	APIKeyEnv string  `yaml:"api_key_env"`
+	VoiceID   string  `yaml:"voice_id"`
+	ModelID   string  `yaml:"model_id"`
+	Stability float64 `yaml:"stability"`
+	Style     float64 `yaml:"style"`
+}
+
+// OpenAIConfig configures the providers/openaitts backend.
+type OpenAIConfig struct {
+	APIKeyEnv string `yaml:"api_key_env"`
+	Voice     string `yaml:"voice"`
+	Model     string `yaml:"model"`
+}
+
+// AzureConfig configures the providers/azuretts backend.
+type AzureConfig struct {
+	SubscriptionKeyEnv string `yaml:"subscription_key_env"`
+	Region             string `yaml:"region"`
+	VoiceName          string `yaml:"voice_name"`
+}
+
+// PiperConfig configures the providers/piper backend (a local HTTP server).
+type PiperConfig struct {
+	BaseURL string `yaml:"base_url"`
+	Voice   string `yaml:"voice"`
+}
+
+type VisualsConfig struct {
+	VideoResolution           string  `yaml:"video_resolution"`
+	FPS                       int     `yaml:"fps"`
+	ProofAspectRatio          string  `yaml:"proof_aspect_ratio"`
+	ProofPosition             string  `yaml:"proof_position"`
+	ProofSlideDirection       string  `yaml:"proof_slide_direction"`
+	ProofAnimationDurationSec float64 `yaml:"proof_animation_duration_sec"`
+	ProofHoldSecDefault       float64 `yaml:"proof_hold_sec_default"`
+	BackgroundDimDuringProof  float64 `yaml:"background_dim_during_proof"`
+	ProofCornerRadius         int     `yaml:"proof_corner_radius"`
+	ProofShadow               bool    `yaml:"proof_shadow"`
+	KenBurnsZoomFactor        float64 `yaml:"ken_burns_zoom_factor"`
+	// Motion selects the motion treatment applied to still visuals: "none"
+	// (static scale/pad, no animation), "kenburns" (default — diagonal
+	// zoom/pan), or "parallax" (a depth-free two-layer zoom/pan approximation
+	// of 2.5D parallax — see Assembler.prepareImageWithParallax).
+	Motion string `yaml:"motion"`
+	// MotionIntensity scales how far Motion's zoom/pan travels; 0 (default)
+	// means the full, unscaled KenBurnsZoomFactor range.
+	MotionIntensity float64 `yaml:"motion_intensity"`
+	// Encoder selects the ffmpeg video encoder: "auto" (probe and pick the
+	// best hardware encoder available), "cpu", "nvenc", "vaapi", "qsv",
+	// "videotoolbox", or "amf". See the hwaccel package.
+	Encoder  string `yaml:"encoder"`
+	Quality  int    `yaml:"quality"`   // mapped to -cq/-qp/-global_quality/-crf per encoder
+	HWDevice string `yaml:"hw_device"` // e.g. /dev/dri/renderD128 for vaapi
+
+	// HeadlessBrowser configures the visuals/webdriver chromedp fallback
+	// ProofScraper uses when a candidate page's images only render via
+	// JavaScript.
+	HeadlessBrowser HeadlessBrowserConfig `yaml:"headless_browser"`
+
+	// Proof configures ProofScraper's pluggable ProofSource backends —
+	// which are enabled and how heavily each is weighted when ranking
+	// candidates gathered across sources.
+	Proof ProofConfig `yaml:"proof"`
+
+	// Subtitles configures the burned-in per-scene captions Assembler adds
+	// to each clip/Ken Burns pan while assembling visuals, independent of
+	// the 05_subtitles stage's full-video subtitle track.
+	Subtitles SceneSubtitlesConfig `yaml:"subtitles"`
+
+	// Thumbnail configures ThumbnailGenerator's A/B candidate thumbnails,
+	// generated and attached after STAGE 9's upload completes.
+	Thumbnail ThumbnailConfig `yaml:"thumbnail"`
+
+	// NLP configures ProofScraper's entity extraction: by default,
+	// nlp.ExtractEntities' in-process capitalization/gazetteer heuristic;
+	// when NLP.SidecarURL is set, an external spaCy/Stanza HTTP sidecar
+	// instead, for a pipeline that wants real NER model accuracy.
+	NLP NLPConfig `yaml:"nlp"`
+
+	// DramaticMode selects how "dramatic" scenes get their visual: "image"
+	// (default — PollinationsFetcher stills + Ken Burns pan), "video"
+	// (VideoGenerator clips; NewVideoGenerator errors if VideoGen's API key
+	// isn't set), or "auto" (use VideoGenerator when its API key is set,
+	// otherwise silently stay on stills). Either way, a video-gen job that
+	// errors at runtime — timeout, out of credits — still degrades that
+	// scene to a Pollinations still rather than failing the run.
+	DramaticMode string `yaml:"dramatic_mode"`
+	// VideoGen configures the videogen.Runway text-to-video/image-to-video
+	// backend VideoGenerator drives. See 04_visuals/videogen.
+	VideoGen VideoGenConfig `yaml:"video_gen"`
+}
+
+// VideoGenConfig configures the videogen.Runway backend.
+type VideoGenConfig struct {
+	APIKeyEnv string `yaml:"api_key_env"`
+	Model     string `yaml:"model"`
+	// Mode selects "text" (default — pure text-to-video) or "image"
+	// (image-to-video: seed a Pollinations keyframe first, then animate it).
+	Mode string `yaml:"mode"`
+	// MaxConcurrent bounds simultaneous in-flight generation jobs so a run
+	// doesn't blow through provider credits; 0 means 1.
+	MaxConcurrent int `yaml:"max_concurrent"`
+	// PollIntervalSec is the base delay between job-status polls; 0 means 5.
+	PollIntervalSec int `yaml:"poll_interval_sec"`
+	// MaxPollAttempts bounds how long one job is polled before giving up;
+	// 0 means 60 (~5 minutes at the default interval).
+	MaxPollAttempts int `yaml:"max_poll_attempts"`
+}
+
+// NLPConfig configures the nlp package's optional spaCy/Stanza HTTP
+// sidecar backend, for a caller wanting real NER model accuracy instead of
+// nlp.ExtractEntities' built-in capitalization/gazetteer heuristic.
+type NLPConfig struct {
+	// SidecarURL, when set (e.g. "http://localhost:8000"), points at an
+	// HTTP service exposing POST /ents — body {"text": "..."}, response
+	// {"people": [...], "places": [...], "orgs": [...], "dates": [...]} —
+	// that nlp.Sidecar calls instead of the in-process heuristic. Empty
+	// (the default) keeps ExtractEntities.
+	SidecarURL string `yaml:"sidecar_url"`
+	// TimeoutSec bounds one sidecar request; 0 means 5.
+	TimeoutSec int `yaml:"timeout_sec"`
+}
+
+// ThumbnailConfig configures visuals.ThumbnailGenerator's A/B candidate
+// thumbnails.
+type ThumbnailConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Candidates is how many A/B variants to generate; 0 means 4.
+	Candidates int `yaml:"candidates"`
+	// FontPath is a TTF file used to composite the title onto each
+	// candidate; empty means DejaVu Sans Bold, a common Linux default.
+	FontPath string `yaml:"font_path"`
+	// FontSize is the title's point size; 0 means 72.
+	FontSize int `yaml:"font_size"`
+	// TextColor/StrokeColor are hex RGB (e.g. "#FFFFFF"); empty means
+	// white text with a black stroke.
+	TextColor   string `yaml:"text_color"`
+	StrokeColor string `yaml:"stroke_color"`
+	// StrokeWidthPx is the outline thickness in pixels; 0 means 3.
+	StrokeWidthPx int `yaml:"stroke_width_px"`
+	// WinnerIndex force-picks a 1-based candidate to attach via
+	// Thumbnails.Set instead of ThumbnailGenerator's own rotation; 0 (the
+	// default) means auto-rotate, spreading candidates across runs by
+	// hashing the run ID rather than always attaching the first one.
+	WinnerIndex int `yaml:"winner_index"`
+}
+
+// SceneSubtitlesConfig styles the captions visuals.Assembler burns into
+// each scene's own clip, so every scene is caption-ready before render
+// instead of relying solely on the final whole-video subtitle burn.
+type SceneSubtitlesConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Style selects "phrase" (default, a few words per line) or "word"
+	// (one word on screen at a time).
+	Style           string  `yaml:"style"`
+	MaxCharsPerLine int     `yaml:"max_chars_per_line"`
+	Font            string  `yaml:"font"`
+	FontSize        int     `yaml:"font_size"`
+	Color           string  `yaml:"color"` // ASS &HBBGGRR& PrimaryColour for force_style
+	StrokeWidth     float64 `yaml:"stroke_width"`
+	MarginBottom    int     `yaml:"margin_bottom"`
+}
+
+// HeadlessBrowserConfig configures the visuals/webdriver package's headless
+// Chrome instance.
+type HeadlessBrowserConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// TimeoutSec bounds how long one page render may take; 0 means 20.
+	TimeoutSec int `yaml:"timeout_sec"`
+	// UserAgent overrides Chrome's default UA string; empty keeps it.
+	UserAgent string `yaml:"user_agent"`
+	// WaitSelector, if set, is a CSS selector Fetch waits to become visible
+	// before reading the page, on top of its network-idle wait.
+	WaitSelector string `yaml:"wait_selector"`
+}
+
+// ProofConfig configures visuals.ProofScraper's pluggable ProofSource
+// backends (Wikipedia, SerpAPI Google Images, Bing, Imgur, DuckDuckGo).
+type ProofConfig struct {
+	// Sources maps a ProofSource.Name() (e.g. "wikipedia", "serpapi") to
+	// its own disabled/weight override. A source missing from this map
+	// uses its built-in default (see ProofScraper's registration logic) —
+	// registered if its required API key/env var is set (Wikipedia and
+	// DuckDuckGo need none), weight 1.0.
+	Sources map[string]ProofSourceConfig `yaml:"sources"`
+	// TimeoutSec bounds each source's Search call; a slow/hung backend
+	// can't block the others. 0 means 8.
+	TimeoutSec int `yaml:"timeout_sec"`
+	// DomainAllowlist scores a candidate higher when its page URL's host
+	// contains one of these substrings (e.g. "wikipedia.org",
+	// "reuters.com"); empty scores every domain equally.
+	DomainAllowlist []string `yaml:"domain_allowlist"`
+	// SearXNG configures the searxngSource backend's public-instance
+	// discovery.
+	SearXNG SearXNGConfig `yaml:"searxng"`
+}
+
+// SearXNGConfig configures searxngSource, the free SerpAPI alternative
+// that queries a public SearXNG meta-search instance instead of a paid
+// API.
+type SearXNGConfig struct {
+	// BaseURL, when set, is a self-hosted SearXNG instance to query
+	// directly (with a trailing slash, e.g. "https://searx.example.com/"),
+	// bypassing searx.space instance discovery entirely.
+	BaseURL string `yaml:"base_url"`
+}
+
+// ProofSourceConfig overrides one ProofSource's default registration and
+// scoring weight. Disabled (not Enabled) so that setting only Weight in
+// YAML can't accidentally zero-value its way into disabling the source.
+type ProofSourceConfig struct {
+	Disabled bool `yaml:"disabled"`
+	// Weight multiplies this source's candidates' scores; 0 means 1.0.
+	Weight float64 `yaml:"weight"`
+}
+
+type AssetsConfig struct {
+	NeverRepeatInSameVideo bool    `yaml:"never_repeat_in_same_video"`
+	ClipTrimMode           string  `yaml:"clip_trim_mode"`
+	LoopCrossfadeSec       float64 `yaml:"loop_crossfade_sec"`
+	FallbackIfNoMatch      string  `yaml:"fallback_if_no_match"`
+}
+
+type SubtitlesConfig struct {
+	Engine        string  `yaml:"engine"`
+	WhisperModel  string  `yaml:"whisper_model"`
+	BurnIntoVideo bool    `yaml:"burn_into_video"`
+	Font          string  `yaml:"font"`
+	FontSize      int     `yaml:"font_size"`
+	FontWeight    string  `yaml:"font_weight"`
+	Color         string  `yaml:"color"`
+	StrokeColor   string  `yaml:"stroke_color"`
+	StrokeWidth   float64 `yaml:"stroke_width"`
+	// Shadow is the ASS drop-shadow distance in pixels; 0 (default) means no
+	// shadow, matching this subsystem's original hardcoded behavior.
+	Shadow float64 `yaml:"shadow"`
+	// Position selects "bottom" (default), "top", or "middle"/"center" — the
+	// ASS numpad Alignment this subtitle style renders at.
+	Position        string `yaml:"position"`
+	MarginBottom    int    `yaml:"margin_bottom"`
+	MaxCharsPerLine int    `yaml:"max_chars_per_line"`
+	// Style selects the rendering path: "srt" (plain, default), "karaoke"
+	// (word-level highlight with \k fallback tags) or "wordpop" (word-level
+	// highlight, no karaoke fill tags).
+	Style       string  `yaml:"style"`
+	AccentColor string  `yaml:"accent_color"` // ASS &HBBGGRR& colour for the active word
+	ActiveScale float64 `yaml:"active_scale"` // e.g. 1.10 for \fscx110\fscy110 pop
+	// SecondaryColor is the ASS SecondaryColour field (the un-sung portion of
+	// a native \k karaoke fill); empty keeps this subsystem's original
+	// hardcoded default.
+	SecondaryColor string `yaml:"secondary_color"`
+	// DualLanguage, if enabled, adds a second translated subtitle track
+	// stacked above the primary one.
+	DualLanguage DualLanguageConfig `yaml:"dual_language"`
+}
+
+// DualLanguageConfig configures a second, translated subtitle track burned
+// in alongside the primary one — e.g. an English karaoke track with a
+// Spanish translation stacked above it — for viewers who don't speak the
+// narration's language.
+type DualLanguageConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Language is the target language to translate each cue into (e.g.
+	// "Spanish" or a BCP-47 code); passed straight into the Groq prompt.
+	Language string `yaml:"language"`
+	// GroqModel overrides the model used for cue translation; empty means
+	// "llama-3.1-8b-instant".
+	GroqModel string `yaml:"groq_model"`
+}
+
+type SFXConfig struct {
+	Enabled              bool              `yaml:"enabled"`
+	VolumeUnderNarration float64           `yaml:"volume_under_narration"`
+	FadeInSec            float64           `yaml:"fade_in_sec"`
+	FadeOutSec           float64           `yaml:"fade_out_sec"`
+	MoodToSFXMap         map[string]string `yaml:"mood_to_sfx_map"`
+	Ducking              DuckingConfig     `yaml:"ducking"`
+}
+
+// DuckingConfig tunes the sidechaincompress filter that ducks the SFX bus
+// under the narration track in Matcher.MixWithNarration.
+type DuckingConfig struct {
+	Threshold float64 `yaml:"threshold"`
+	Ratio     float64 `yaml:"ratio"`
+	Attack    float64 `yaml:"attack"`
+	Release   float64 `yaml:"release"`
+	Makeup    float64 `yaml:"makeup"`
+}
+
+// MusicConfig configures sfx.Matcher's background music bed: a per-scene
+// track picked by mood (like SFXConfig.MoodToSFXMap), looped/trimmed to each
+// scene's duration, then crossfaded into one continuous bed across scene
+// boundaries and ducked under the narration alongside the SFX bus.
+type MusicConfig struct {
+	Enabled        bool    `yaml:"enabled"`
+	VolumeUnderMix float64 `yaml:"volume_under_mix"`
+	// CrossfadeSec is how long each scene-to-scene acrossfade blends; 0
+	// means 1.5s.
+	CrossfadeSec   float64           `yaml:"crossfade_sec"`
+	MoodToMusicMap map[string]string `yaml:"mood_to_music_map"`
+}
+
+type MetadataConfig struct {
+	GroqModel               string `yaml:"groq_model"`
+	TitleMaxChars           int    `yaml:"title_max_chars"`
+	DescriptionWordCount    int    `yaml:"description_word_count"`
+	TagsCount               int    `yaml:"tags_count"`
+	YouTubeCategoryID       string `yaml:"youtube_category_id"`
+	GenerateThumbnailPrompt bool   `yaml:"generate_thumbnail_prompt"`
+	// TitleVariants is how many A/B title options Generator.Run asks Groq
+	// for in one call; 0 means 3. VideoMetadata.Title is always variant 0.
+	TitleVariants int `yaml:"title_variants"`
+}
+
+type UploadConfig struct {
+	Visibility        string    `yaml:"visibility"`
+	ScheduleTimeEST   string    `yaml:"schedule_time_est"`
+	NotifySubscribers bool      `yaml:"notify_subscribers"`
+	MadeForKids       bool      `yaml:"made_for_kids"`
+	DefaultLanguage   string    `yaml:"default_language"`
+	HLS               HLSConfig `yaml:"hls"`
+	// ChunkSizeMB sizes each resumable-upload PUT; 0 means 8 MiB.
+	ChunkSizeMB int `yaml:"chunk_size_mb"`
+}
+
+// HLSConfig configures the packaging/hls Packager, an optional stage that
+// packages the final MP4 into a multi-bitrate HLS ladder for CDN/preview
+// delivery alongside the YouTube upload.
+type HLSConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// SegmentSec is the target segment duration in seconds; 0 means 6.
+	SegmentSec int `yaml:"segment_sec"`
+	// SegmentType is "mpegts" (default, .ts segments) or "fmp4" (.m4s segments).
+	SegmentType string         `yaml:"segment_type"`
+	Renditions  []HLSRendition `yaml:"renditions"`
+	// Encrypt turns on AES-128 segment encryption with a freshly generated
+	// key per run; see packaging/hls.Packager.Package.
+	Encrypt bool `yaml:"encrypt"`
+	// KeyURITemplate is the EXT-X-KEY URI written into the .keyinfo file.
+	// The literal token "{prefix}" is replaced with this run's random
+	// session prefix, so it can point at a per-run key-serving endpoint,
+	// e.g. "https://keys.example.com/{prefix}/enc.key". Empty means the
+	// key is served as a plain relative file, "enc.key".
+	KeyURITemplate string `yaml:"key_uri_template"`
+	// Codec selects the video encoder for every rendition: "h264" (default,
+	// libx264), "hevc" (libx265), or "av1" (libsvtav1).
+	Codec string `yaml:"codec"`
+	// DASH additionally packages the same renditions as a DASH manifest
+	// (dash.mpd) alongside the HLS master playlist, for players that prefer
+	// MPEG-DASH over HLS.
+	DASH bool `yaml:"dash"`
+}
+
+// HLSRendition is one bitrate/resolution rung in the HLS ladder.
+type HLSRendition struct {
+	Height       int    `yaml:"height"`
+	VideoBitrate string `yaml:"video_bitrate"` // ffmpeg -b:v value, e.g. "2500k"
+	AudioBitrate string `yaml:"audio_bitrate"` // ffmpeg -b:a value, e.g. "128k"
+}
+
+type ScheduleConfig struct {
+	TuesdayCron string `yaml:"tuesday_cron"`
+	FridayCron  string `yaml:"friday_cron"`
+}
+
+type PathsConfig struct {
+	AssetsVideo    string `yaml:"assets_video"`
+	AssetsSFX      string `yaml:"assets_sfx"`
+	VideoTags      string `yaml:"video_tags"`
+	SFXTags        string `yaml:"sfx_tags"`
+	ClipUsageLog   string `yaml:"clip_usage_log"`
+	UsedStoriesLog string `yaml:"used_stories_log"`
+	RSSFeedState   string `yaml:"rss_feed_state"`
+	Output         string `yaml:"output"`
+	Logs           string `yaml:"logs"`
+}
+
+// Load reads config.yaml and returns a Config struct
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
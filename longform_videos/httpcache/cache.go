@@ -0,0 +1,300 @@
+// Package httpcache is a content-addressed on-disk cache for outbound HTTP
+// requests, shared by research.Scraper's Sources (NewsAPI in particular has
+// a hard daily quota) and visuals.PollinationsFetcher, both of which
+// otherwise re-issue identical requests across runs.
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"true-crime-pipeline/config"
+)
+
+// ctxKey is the unexported type for the context value carrying a Cache, so
+// other packages can't collide with it by accident.
+type ctxKey struct{}
+
+// WithCache attaches c to ctx so a Source.Fetch can recover it via
+// FromContext without widening the Source interface.
+func WithCache(ctx context.Context, c *Cache) context.Context {
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// FromContext returns the Cache attached via WithCache, or nil if none was.
+func FromContext(ctx context.Context) *Cache {
+	c, _ := ctx.Value(ctxKey{}).(*Cache)
+	return c
+}
+
+// Stats is a snapshot of a Cache's hit/miss counters.
+type Stats struct {
+	Hits   int64
+	Misses int64
+}
+
+// Cache keys entries on method+URL+body (sha256 hex), storing each
+// response's body under <Path>/<key>.bin and its status/headers/expiry in
+// a sidecar <Path>/<key>.json. Once the directory exceeds MaxSizeMiB,
+// entries are evicted oldest-last-access-first.
+type Cache struct {
+	enabled      bool
+	dir          string
+	lifetime     time.Duration
+	maxSizeBytes int64
+
+	mu     sync.Mutex
+	hits   int64
+	misses int64
+}
+
+// New builds a Cache from cfg. A disabled cfg still returns a usable Cache
+// whose Do/Transport simply pass every request straight through.
+func New(cfg config.CacheConfig) *Cache {
+	lifetime := time.Duration(cfg.LifetimeHours) * time.Hour
+	if lifetime <= 0 {
+		lifetime = 24 * time.Hour
+	}
+	maxSizeMiB := cfg.MaxSizeMiB
+	if maxSizeMiB <= 0 {
+		maxSizeMiB = 500
+	}
+	return &Cache{
+		enabled:      cfg.Enabled,
+		dir:          cfg.Path,
+		lifetime:     lifetime,
+		maxSizeBytes: int64(maxSizeMiB) * 1024 * 1024,
+	}
+}
+
+// Stats returns the cache's hit/miss counts so far.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Hits: c.hits, Misses: c.misses}
+}
+
+// Do executes req via client, serving a fresh cached response instead when
+// one exists. It's a drop-in replacement for client.Do.
+func (c *Cache) Do(client *http.Client, req *http.Request) (*http.Response, error) {
+	return c.fetch(req, client.Do)
+}
+
+// Do executes req via client, consulting the Cache attached to ctx (see
+// WithCache) if any. With none attached — e.g. in tests — it behaves like
+// a plain client.Do.
+func Do(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	if c := FromContext(ctx); c != nil {
+		return c.Do(client, req)
+	}
+	return client.Do(req)
+}
+
+// Transport wraps base in an http.RoundTripper backed by this Cache, for
+// callers (like gofeed.Parser) that own their *http.Client and only expose
+// a Transport seam.
+func (c *Cache) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cachingTransport{cache: c, base: base}
+}
+
+type cachingTransport struct {
+	cache *Cache
+	base  http.RoundTripper
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.cache.fetch(req, t.base.RoundTrip)
+}
+
+// entryMeta is the sidecar JSON stored next to each cached response body.
+type entryMeta struct {
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header"`
+	Expiry     time.Time   `json:"expiry"`
+	LastAccess time.Time   `json:"last_access"`
+	Size       int64       `json:"size"`
+}
+
+// fetch is the shared cache logic behind both Do and Transport: look up
+// key(req) on disk, serve it on a fresh hit, otherwise perform the request
+// via do and cache a 200 response.
+func (c *Cache) fetch(req *http.Request, do func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	if !c.enabled {
+		return do(req)
+	}
+
+	key, body, err := cacheKey(req)
+	if err != nil {
+		return do(req)
+	}
+	if body != nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	binFile := filepath.Join(c.dir, key+".bin")
+	metaFile := filepath.Join(c.dir, key+".json")
+
+	if meta, ok := readMeta(metaFile); ok && time.Now().Before(meta.Expiry) {
+		if data, err := os.ReadFile(binFile); err == nil {
+			c.recordHit()
+			meta.LastAccess = time.Now()
+			writeMeta(metaFile, meta)
+			return &http.Response{
+				Status:     http.StatusText(meta.Status),
+				StatusCode: meta.Status,
+				Header:     meta.Header,
+				Body:       io.NopCloser(bytes.NewReader(data)),
+				Request:    req,
+			}, nil
+		}
+	}
+
+	c.recordMiss()
+	resp, err := do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	if resp.StatusCode == http.StatusOK {
+		c.store(key, data, resp.StatusCode, resp.Header)
+	}
+	return resp, nil
+}
+
+func (c *Cache) store(key string, data []byte, status int, header http.Header) {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, key+".bin"), data, 0644); err != nil {
+		return
+	}
+	writeMeta(filepath.Join(c.dir, key+".json"), entryMeta{
+		Status:     status,
+		Header:     header,
+		Expiry:     time.Now().Add(c.lifetime),
+		LastAccess: time.Now(),
+		Size:       int64(len(data)),
+	})
+	c.evict()
+}
+
+// evict removes the oldest-by-LastAccess entries until the cache directory
+// is back under maxSizeBytes.
+func (c *Cache) evict() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	type candidate struct {
+		key        string
+		size       int64
+		lastAccess time.Time
+	}
+	var candidates []candidate
+	var total int64
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		key := strings.TrimSuffix(e.Name(), ".json")
+		meta, ok := readMeta(filepath.Join(c.dir, e.Name()))
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, candidate{key: key, size: meta.Size, lastAccess: meta.LastAccess})
+		total += meta.Size
+	}
+
+	if total <= c.maxSizeBytes {
+		return
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastAccess.Before(candidates[j].lastAccess)
+	})
+	for _, cand := range candidates {
+		if total <= c.maxSizeBytes {
+			break
+		}
+		_ = os.Remove(filepath.Join(c.dir, cand.key+".bin"))
+		_ = os.Remove(filepath.Join(c.dir, cand.key+".json"))
+		total -= cand.size
+	}
+}
+
+func (c *Cache) recordHit() {
+	c.mu.Lock()
+	c.hits++
+	c.mu.Unlock()
+}
+
+func (c *Cache) recordMiss() {
+	c.mu.Lock()
+	c.misses++
+	c.mu.Unlock()
+}
+
+// cacheKey hashes method+URL+body into the entry's filename stem, draining
+// and returning req's body so the caller can restore it for the real
+// request.
+func cacheKey(req *http.Request) (string, []byte, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	h := sha256.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte("\n"))
+	h.Write([]byte(req.URL.String()))
+	h.Write([]byte("\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), body, nil
+}
+
+func readMeta(path string) (entryMeta, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entryMeta{}, false
+	}
+	var meta entryMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return entryMeta{}, false
+	}
+	return meta, true
+}
+
+func writeMeta(path string, meta entryMeta) {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
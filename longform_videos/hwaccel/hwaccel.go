@@ -0,0 +1,173 @@
+// Package hwaccel picks a hardware (or software) H.264 encoder for ffmpeg
+// and builds the ffmpeg args for it, so every render stage in the pipeline
+// (subtitle burn, audio concat, future visuals passes) shares one codec
+// policy instead of each hardcoding "-c:v libx264".
+package hwaccel
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Encoder identifies an ffmpeg video encoder family.
+type Encoder string
+
+const (
+	Auto         Encoder = "auto"
+	CPU          Encoder = "cpu"
+	NVENC        Encoder = "nvenc"
+	VAAPI        Encoder = "vaapi"
+	QSV          Encoder = "qsv"
+	VideoToolbox Encoder = "videotoolbox"
+	AMF          Encoder = "amf"
+)
+
+// codecName is the concrete ffmpeg -c:v value for each Encoder.
+var codecName = map[Encoder]string{
+	NVENC:        "h264_nvenc",
+	VAAPI:        "h264_vaapi",
+	QSV:          "h264_qsv",
+	VideoToolbox: "h264_videotoolbox",
+	AMF:          "h264_amf",
+	CPU:          "libx264",
+}
+
+// priority is the auto-detect preference order when more than one hardware
+// encoder is available on the machine.
+var priority = []Encoder{NVENC, QSV, VAAPI, VideoToolbox, AMF}
+
+// Options configures encoder resolution and arg building.
+type Options struct {
+	Encoder  Encoder // "auto" | "cpu" | "nvenc" | "vaapi" | "qsv" | "videotoolbox"
+	Quality  int     // mapped to -cq/-qp/-global_quality/-crf depending on encoder; 0 means "use default"
+	Preset   string  // libx264/NVENC preset name; 0 means "use default"
+	HWDevice string  // e.g. /dev/dri/renderD128 for VAAPI
+}
+
+var (
+	probeOnce sync.Once
+	available map[Encoder]bool
+)
+
+// probe runs `ffmpeg -hide_banner -encoders` once per process and records
+// which hardware encoders this machine's ffmpeg build actually supports.
+func probe() map[Encoder]bool {
+	probeOnce.Do(func() {
+		available = make(map[Encoder]bool)
+		out, err := exec.Command("ffmpeg", "-hide_banner", "-encoders").Output()
+		if err != nil {
+			log.Printf("[hwaccel] could not probe ffmpeg encoders: %v — assuming CPU only", err)
+			return
+		}
+		listing := string(out)
+		for _, enc := range priority {
+			if strings.Contains(listing, codecName[enc]) {
+				available[enc] = true
+			}
+		}
+		log.Printf("[hwaccel] available hardware encoders: %v", available)
+	})
+	return available
+}
+
+// Resolve turns a requested Encoder ("auto" or explicit) into the concrete
+// Encoder to use, probing ffmpeg and falling back to CPU if the request is
+// unavailable.
+func Resolve(opt Options) Encoder {
+	req := Encoder(strings.ToLower(string(opt.Encoder)))
+	if req == "" {
+		req = Auto
+	}
+	if req == CPU {
+		return CPU
+	}
+
+	avail := probe()
+	if req != Auto {
+		if avail[req] {
+			return req
+		}
+		log.Printf("[hwaccel] requested encoder %q not available on this ffmpeg — falling back to libx264", req)
+		return CPU
+	}
+
+	for _, enc := range priority {
+		if avail[enc] {
+			return enc
+		}
+	}
+	return CPU
+}
+
+// EncoderArgs returns the ffmpeg args for a resolved Encoder: preInput args
+// that must appear before any -i (e.g. VAAPI's device init) and output args
+// (-c:v plus its quality/preset flags).
+func EncoderArgs(enc Encoder, opt Options) (preInput, output []string) {
+	quality := opt.Quality
+	switch enc {
+	case NVENC:
+		preset := opt.Preset
+		if preset == "" {
+			preset = "p4"
+		}
+		if quality == 0 {
+			quality = 23
+		}
+		output = []string{"-c:v", codecName[NVENC], "-preset", preset, "-cq", fmt.Sprint(quality)}
+
+	case QSV:
+		if quality == 0 {
+			quality = 23
+		}
+		output = []string{"-c:v", codecName[QSV], "-global_quality", fmt.Sprint(quality)}
+
+	case VAAPI:
+		device := opt.HWDevice
+		if device == "" {
+			device = "/dev/dri/renderD128"
+		}
+		if quality == 0 {
+			quality = 23
+		}
+		preInput = []string{"-vaapi_device", device}
+		output = []string{"-c:v", codecName[VAAPI], "-qp", fmt.Sprint(quality)}
+
+	case VideoToolbox:
+		if quality == 0 {
+			quality = 23
+		}
+		output = []string{"-c:v", codecName[VideoToolbox], "-q:v", fmt.Sprint(quality)}
+
+	case AMF:
+		if quality == 0 {
+			quality = 23
+		}
+		// -rc cqp is required for -qp_i/-qp_p to take effect at all; AMF's
+		// default rate-control mode otherwise ignores them.
+		output = []string{"-c:v", codecName[AMF], "-quality", "balanced", "-rc", "cqp", "-qp_i", fmt.Sprint(quality), "-qp_p", fmt.Sprint(quality)}
+
+	default:
+		preset := opt.Preset
+		if preset == "" {
+			preset = "fast"
+		}
+		if quality == 0 {
+			quality = 20
+		}
+		output = []string{"-c:v", codecName[CPU], "-preset", preset, "-crf", fmt.Sprint(quality)}
+	}
+	return preInput, output
+}
+
+// VideoFilterSuffix returns the -vf suffix an encoder needs appended after
+// any CPU-side filters (e.g. subtitle burn-in) — VAAPI requires the frame be
+// uploaded to the GPU (format=nv12,hwupload) only after CPU filtering runs.
+func VideoFilterSuffix(enc Encoder) string {
+	if enc == VAAPI {
+		return ",format=nv12,hwupload"
+	}
+	return ""
+}
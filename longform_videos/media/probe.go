@@ -0,0 +1,233 @@
+// Package media probes source files once with ffprobe/ffmpeg and caches the
+// result on disk. Before this package existed, each stage shelled out to
+// ffprobe ad-hoc on every call it needed a duration for — see the duplicated
+// getAudioDuration helpers in 03_audio and 06_sfx — and nothing captured a
+// source's fps/resolution/loudness at all, forcing the render/burn step to
+// fall back to conservative encoder defaults.
+package media
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SourceMetadata is everything the pipeline needs to know about a source
+// media file without re-probing it. FPSNum/FPSDen are kept as the raw
+// ffprobe rational (r_frame_rate isn't always a clean integer); TBN is the
+// stream's time_base denominator, the same value ffmpeg prints as "tbn" in
+// its console banner.
+type SourceMetadata struct {
+	Path         string  `json:"path"`
+	Duration     float64 `json:"duration"`
+	FPSNum       int     `json:"fps_num"`
+	FPSDen       int     `json:"fps_den"`
+	TBN          int     `json:"tbn"`
+	Width        int     `json:"width"`
+	Height       int     `json:"height"`
+	VideoCodec   string  `json:"video_codec"`
+	SampleRate   int     `json:"sample_rate"`
+	Channels     int     `json:"channels"`
+	AudioCodec   string  `json:"audio_codec"`
+	LoudnessLUFS float64 `json:"loudness_lufs"`
+}
+
+// FPS returns the stream frame rate as a plain float, or 0 if the source
+// had no video stream (FPSDen is 0 in that case).
+func (m SourceMetadata) FPS() float64 {
+	if m.FPSDen == 0 {
+		return 0
+	}
+	return float64(m.FPSNum) / float64(m.FPSDen)
+}
+
+// Prober runs ffprobe/ffmpeg against source files and caches the result,
+// keyed by path+mtime, under <outputDir>/.media_cache so a rerun over the
+// same files in the same run directory never re-probes.
+type Prober struct {
+	cacheDir string
+}
+
+// New creates a Prober that caches under outputDir/.media_cache.
+func New(outputDir string) *Prober {
+	return &Prober{cacheDir: filepath.Join(outputDir, ".media_cache")}
+}
+
+// Probe returns path's SourceMetadata, including integrated loudness,
+// probing on a cache miss and persisting the result for next time.
+func (p *Prober) Probe(ctx context.Context, path string) (SourceMetadata, error) {
+	key, err := p.cacheKey(path)
+	if err != nil {
+		return SourceMetadata{}, fmt.Errorf("media: stat %s: %w", path, err)
+	}
+	cacheFile := filepath.Join(p.cacheDir, key+".json")
+
+	if cached, ok := readCache(cacheFile); ok {
+		return cached, nil
+	}
+
+	meta, err := probeStreams(ctx, path)
+	if err != nil {
+		return SourceMetadata{}, fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+
+	meta.LoudnessLUFS, err = measureLoudness(ctx, path)
+	if err != nil {
+		return SourceMetadata{}, fmt.Errorf("measure loudness %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(p.cacheDir, 0755); err == nil {
+		if data, err := json.MarshalIndent(meta, "", "  "); err == nil {
+			_ = os.WriteFile(cacheFile, data, 0644)
+		}
+	}
+
+	return meta, nil
+}
+
+// ProbeStreams returns path's stream/format metadata (duration, resolution,
+// codecs) without measuring loudness or touching the cache — for callers
+// like media/validate that need to check stills and silent video clips,
+// which the full Probe's ebur128 pass would error on for lack of an audio
+// stream.
+func (p *Prober) ProbeStreams(ctx context.Context, path string) (SourceMetadata, error) {
+	meta, err := probeStreams(ctx, path)
+	if err != nil {
+		return SourceMetadata{}, fmt.Errorf("ffprobe %s: %w", path, err)
+	}
+	return meta, nil
+}
+
+// cacheKey derives the sha1(path+mtime) cache filename stem for path.
+func (p *Prober) cacheKey(path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s:%d", path, fi.ModTime().UnixNano())))
+	return fmt.Sprintf("%x", sum), nil
+}
+
+func readCache(cacheFile string) (SourceMetadata, bool) {
+	data, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return SourceMetadata{}, false
+	}
+	var meta SourceMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return SourceMetadata{}, false
+	}
+	return meta, true
+}
+
+// ffprobeOutput mirrors the subset of `ffprobe -show_streams -show_format`
+// JSON this package reads.
+type ffprobeOutput struct {
+	Streams []ffprobeStream `json:"streams"`
+	Format  struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+}
+
+type ffprobeStream struct {
+	CodecType  string `json:"codec_type"`
+	CodecName  string `json:"codec_name"`
+	RFrameRate string `json:"r_frame_rate"`
+	TimeBase   string `json:"time_base"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	SampleRate string `json:"sample_rate"`
+	Channels   int    `json:"channels"`
+}
+
+// probeStreams runs one `ffprobe -show_streams -show_format` pass and
+// extracts duration, fps/tbn/resolution from the video stream, and sample
+// rate/channels from the audio stream.
+func probeStreams(ctx context.Context, path string) (SourceMetadata, error) {
+	out, err := exec.CommandContext(ctx, "ffprobe",
+		"-v", "error",
+		"-print_format", "json",
+		"-show_streams",
+		"-show_format",
+		path,
+	).Output()
+	if err != nil {
+		return SourceMetadata{}, err
+	}
+
+	var probed ffprobeOutput
+	if err := json.Unmarshal(out, &probed); err != nil {
+		return SourceMetadata{}, fmt.Errorf("parse ffprobe json: %w", err)
+	}
+
+	meta := SourceMetadata{Path: path}
+	if d, err := strconv.ParseFloat(strings.TrimSpace(probed.Format.Duration), 64); err == nil {
+		meta.Duration = d
+	}
+
+	for _, s := range probed.Streams {
+		switch s.CodecType {
+		case "video":
+			meta.FPSNum, meta.FPSDen = parseRational(s.RFrameRate)
+			_, meta.TBN = parseRational(s.TimeBase)
+			meta.Width = s.Width
+			meta.Height = s.Height
+			meta.VideoCodec = s.CodecName
+		case "audio":
+			if sr, err := strconv.Atoi(s.SampleRate); err == nil {
+				meta.SampleRate = sr
+			}
+			meta.Channels = s.Channels
+			meta.AudioCodec = s.CodecName
+		}
+	}
+
+	return meta, nil
+}
+
+// parseRational splits ffprobe's "num/den" fields (r_frame_rate, time_base)
+// into their two halves.
+func parseRational(s string) (num, den int) {
+	before, after, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, 0
+	}
+	num, _ = strconv.Atoi(before)
+	den, _ = strconv.Atoi(after)
+	return num, den
+}
+
+// lufsRe matches ebur128's "I: -23.1 LUFS" integrated-loudness line.
+var lufsRe = regexp.MustCompile(`I:\s*(-?[\d.]+) LUFS`)
+
+// measureLoudness runs a throwaway `ffmpeg -af ebur128 -f null -` pass and
+// parses the integrated loudness (LUFS) out of its stderr summary.
+func measureLoudness(ctx context.Context, path string) (float64, error) {
+	out, err := exec.CommandContext(ctx, "ffmpeg",
+		"-i", path,
+		"-af", "ebur128",
+		"-f", "null", "-",
+	).CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", err, string(out))
+	}
+
+	matches := lufsRe.FindAllStringSubmatch(string(out), -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("no integrated loudness found in ebur128 output")
+	}
+	// ebur128 logs one "I:" line per interval plus a final summary block —
+	// only the last line is the integrated-loudness-over-whole-file value.
+	lufs, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+	if err != nil {
+		return 0, err
+	}
+	return lufs, nil
+}
@@ -0,0 +1,105 @@
+// Package validate enforces per-asset invariants on generated media before
+// it reaches assembly or upload. Pollinations occasionally returns a
+// valid-looking 200 with a tiny error PNG, and TTS providers sometimes hand
+// back truncated audio — neither fails loudly on its own, so callers probe
+// the result with ffprobe (via media.Prober) and reject it here instead of
+// baking a broken asset into the final render.
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"true-crime-pipeline/media"
+)
+
+// MinImageWidth/MinImageHeight are the minimum resolution a generated still
+// must clear — below this it reads as a thumbnail-sized error placeholder,
+// not a real scene image.
+const (
+	MinImageWidth  = 1280
+	MinImageHeight = 720
+)
+
+// Image checks that path is a real image stream at least
+// MinImageWidth x MinImageHeight.
+func Image(ctx context.Context, prober *media.Prober, path string) error {
+	meta, err := prober.ProbeStreams(ctx, path)
+	if err != nil {
+		return fmt.Errorf("probe image: %w", err)
+	}
+	if meta.Width == 0 || meta.Height == 0 {
+		return fmt.Errorf("no image stream found")
+	}
+	if meta.Width < MinImageWidth || meta.Height < MinImageHeight {
+		return fmt.Errorf("image is %dx%d, smaller than the %dx%d minimum", meta.Width, meta.Height, MinImageWidth, MinImageHeight)
+	}
+	return nil
+}
+
+// Audio checks that path's duration is within tolerance (e.g. 0.15 for
+// ±15%) of expectedSec, catching a TTS provider that handed back truncated
+// or looping audio. expectedSec <= 0 skips the check — there's nothing to
+// compare against yet.
+func Audio(ctx context.Context, prober *media.Prober, path string, expectedSec, tolerance float64) error {
+	if expectedSec <= 0 {
+		return nil
+	}
+	meta, err := prober.ProbeStreams(ctx, path)
+	if err != nil {
+		return fmt.Errorf("probe audio: %w", err)
+	}
+	if meta.Duration <= 0 {
+		return fmt.Errorf("no audio duration reported")
+	}
+	if diff := (meta.Duration - expectedSec) / expectedSec; diff > tolerance || diff < -tolerance {
+		return fmt.Errorf("audio is %.1fs, outside ±%.0f%% of the expected %.1fs", meta.Duration, tolerance*100, expectedSec)
+	}
+	return nil
+}
+
+// VideoClip checks that path is an H.264 video stream at least
+// minDurationSec long — enough to cover the scene it's being trimmed or
+// looped to fill.
+func VideoClip(ctx context.Context, prober *media.Prober, path string, minDurationSec float64) error {
+	meta, err := prober.ProbeStreams(ctx, path)
+	if err != nil {
+		return fmt.Errorf("probe video clip: %w", err)
+	}
+	if meta.Width == 0 || meta.Height == 0 {
+		return fmt.Errorf("no video stream found")
+	}
+	if meta.VideoCodec != "h264" {
+		return fmt.Errorf("video codec is %q, expected h264", meta.VideoCodec)
+	}
+	if meta.Duration < minDurationSec {
+		return fmt.Errorf("video clip is %.1fs, shorter than the %.1fs it needs to cover", meta.Duration, minDurationSec)
+	}
+	return nil
+}
+
+// FinalVideo checks the fully assembled output before it's handed to
+// upload.Uploader: total duration within toleranceSec of expectedTotalSec,
+// an H.264 video stream, and an AAC audio stream.
+func FinalVideo(ctx context.Context, prober *media.Prober, path string, expectedTotalSec, toleranceSec float64) error {
+	meta, err := prober.ProbeStreams(ctx, path)
+	if err != nil {
+		return fmt.Errorf("probe final video: %w", err)
+	}
+	if meta.Width == 0 || meta.Height == 0 {
+		return fmt.Errorf("final video has no video stream")
+	}
+	if meta.VideoCodec != "h264" {
+		return fmt.Errorf("final video codec is %q, expected h264", meta.VideoCodec)
+	}
+	if meta.SampleRate == 0 {
+		return fmt.Errorf("final video has no audio stream")
+	}
+	if meta.AudioCodec != "aac" {
+		return fmt.Errorf("final audio codec is %q, expected aac", meta.AudioCodec)
+	}
+	if diff := meta.Duration - expectedTotalSec; diff > toleranceSec || diff < -toleranceSec {
+		return fmt.Errorf("final video is %.1fs, expected %.1fs ± %.0fs", meta.Duration, expectedTotalSec, toleranceSec)
+	}
+	return nil
+}
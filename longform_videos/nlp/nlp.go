@@ -0,0 +1,293 @@
+// Package nlp extracts structured named entities from scene narration, so
+// visuals.ProofScraper can build targeted search queries ("Ted Bundy Chi
+// Omega") instead of the old extractSearchQuery's "words longer than 3
+// chars that aren't filler" ("police found evidence bedroom").
+//
+// ExtractEntities is a lightweight, pure-Go capitalization/gazetteer
+// tagger rather than a real CRF/spaCy model — it runs in-process with no
+// extra dependency or sidecar to operate, at the cost of being fooled by
+// sentence-initial capitalization and title-cased non-entities a real NER
+// model wouldn't miss. That tradeoff mirrors extractSearchQuery's own
+// heuristic precedent; a caller wanting real NER accuracy instead can
+// configure config.NLPConfig.SidecarURL and use Sidecar, which satisfies
+// this package's Query contract from a spaCy/Stanza HTTP service without
+// ProofScraper needing to change.
+package nlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Query is the structured result of ExtractEntities: the people, places,
+// organizations and dates a chunk of narration mentions, classified
+// best-effort, plus a Keywords bag of meaningful terms that didn't land in
+// any of those. Every slice preserves first-occurrence order with
+// duplicates removed, so index 0 is each category's "top" entity.
+type Query struct {
+	People   []string
+	Places   []string
+	Orgs     []string
+	Dates    []string
+	Keywords []string
+}
+
+// TopPerson returns q's first-occurrence PERSON entity, or "" if none was
+// found.
+func (q Query) TopPerson() string {
+	return first(q.People)
+}
+
+// TopPlace returns q's first-occurrence LOC entity, or "" if none was
+// found.
+func (q Query) TopPlace() string {
+	return first(q.Places)
+}
+
+func first(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[0]
+}
+
+// orgSuffixes are trailing words in a capitalized run that mark it as an
+// organization rather than a person's name.
+var orgSuffixes = map[string]bool{
+	"Police": true, "Department": true, "Bureau": true, "Agency": true,
+	"University": true, "College": true, "Hospital": true, "Corporation": true,
+	"Corp": true, "Inc": true, "Company": true, "Court": true, "Office": true,
+	"Church": true, "Academy": true, "Sheriff": true, "Commission": true,
+}
+
+// placeSuffixes are trailing words in a capitalized run that mark it as a
+// place rather than a person's name.
+var placeSuffixes = map[string]bool{
+	"City": true, "County": true, "State": true, "Street": true, "Avenue": true,
+	"Road": true, "Boulevard": true, "Park": true, "Lake": true, "River": true,
+	"Island": true, "Valley": true, "Beach": true, "Mountain": true, "Heights": true,
+}
+
+// knownPlaces are common standalone place names this narration is likely to
+// mention without a recognizable suffix (US states, a few always-a-place
+// nouns) — a small gazetteer, not an exhaustive list.
+var knownPlaces = map[string]bool{
+	"America": true, "California": true, "Texas": true, "Florida": true,
+	"Ohio": true, "Oregon": true, "Washington": true, "Chicago": true,
+	"England": true, "London": true, "Seattle": true, "Portland": true,
+}
+
+// monthNames backs dateRegex below.
+var monthNames = `January|February|March|April|May|June|July|August|September|October|November|December`
+
+// dateRegex matches "Month Day[, ]Year" and bare four-digit years in the
+// 1900-2099 range the narration is realistically set in — independent of
+// capitalization-run detection below, since "March 14, 2019" has two
+// non-capitalized tokens a run-based tagger would otherwise miss entirely.
+var dateRegex = regexp.MustCompile(`\b(?:(?:` + monthNames + `)\s+\d{1,2}(?:st|nd|rd|th)?,?\s+(?:19|20)\d{2}|(?:19|20)\d{2})\b`)
+
+// fillerWords are common short/function words extractSearchQuery used to
+// reject — reused here as Keywords' fallback filter for narration that
+// named no entities at all.
+var fillerWords = map[string]bool{
+	"the": true, "a": true, "an": true, "was": true, "were": true, "had": true,
+	"have": true, "has": true, "her": true, "his": true, "their": true,
+	"they": true, "she": true, "he": true, "it": true, "this": true,
+	"that": true, "and": true, "or": true, "but": true, "for": true,
+	"from": true, "with": true, "into": true, "nobody": true, "somebody": true,
+	"everyone": true, "anyone": true, "three": true, "two": true,
+}
+
+// ExtractEntities tags text's capitalized word-runs as People, Places or
+// Orgs by the gazetteers above (defaulting an unrecognized run to People,
+// since true-crime narration's proper nouns are overwhelmingly names),
+// pulls Dates via dateRegex, and fills Keywords from whatever meaningful
+// words neither of those claimed.
+func ExtractEntities(text string) Query {
+	var q Query
+	seenPeople, seenPlaces, seenOrgs, seenDates := map[string]bool{}, map[string]bool{}, map[string]bool{}, map[string]bool{}
+
+	for _, m := range dateRegex.FindAllString(text, -1) {
+		if !seenDates[m] {
+			seenDates[m] = true
+			q.Dates = append(q.Dates, m)
+		}
+	}
+
+	claimed := map[string]bool{}
+	words := strings.Fields(text)
+	atSentenceStart := true
+	for i := 0; i < len(words); i++ {
+		clean, trail := trimPunct(words[i])
+		if !isCapitalized(clean) {
+			atSentenceStart = trail != ""
+			continue
+		}
+
+		run := []string{clean}
+		runWasSentenceStart := atSentenceStart
+		j := i + 1
+		for j < len(words) {
+			nextClean, nextTrail := trimPunct(words[j])
+			if !isCapitalized(nextClean) {
+				break
+			}
+			run = append(run, nextClean)
+			j++
+			if nextTrail != "" {
+				break
+			}
+		}
+
+		// A lone capitalized word sitting at a sentence boundary is most
+		// likely just that sentence's normal capitalization, not a named
+		// entity — skip it unless it's part of a multi-word run, which a
+		// plain sentence-initial capital never produces on its own.
+		if len(run) > 1 || !runWasSentenceStart {
+			name := strings.Join(run, " ")
+			last := run[len(run)-1]
+			switch {
+			case orgSuffixes[last]:
+				if !seenOrgs[name] {
+					seenOrgs[name] = true
+					q.Orgs = append(q.Orgs, name)
+				}
+			case placeSuffixes[last] || (len(run) == 1 && knownPlaces[last]):
+				if !seenPlaces[name] {
+					seenPlaces[name] = true
+					q.Places = append(q.Places, name)
+				}
+			default:
+				if !seenPeople[name] {
+					seenPeople[name] = true
+					q.People = append(q.People, name)
+				}
+			}
+			for _, w := range run {
+				claimed[strings.ToLower(w)] = true
+			}
+		}
+
+		i = j - 1
+		_, lastTrail := trimPunct(words[i])
+		atSentenceStart = lastTrail != ""
+	}
+
+	for _, w := range words {
+		clean, _ := trimPunct(w)
+		lower := strings.ToLower(clean)
+		if clean == "" || claimed[lower] || fillerWords[lower] || len(clean) <= 3 {
+			continue
+		}
+		if len(q.Keywords) >= 4 {
+			break
+		}
+		q.Keywords = append(q.Keywords, lower)
+	}
+
+	return q
+}
+
+// Sidecar calls an external spaCy/Stanza HTTP NER service instead of
+// ExtractEntities' in-process heuristic, for a caller that's willing to run
+// (or already runs) a real model and wants its accuracy — see
+// ExtractEntities' doc comment for the tradeoff this exists to opt out of.
+type Sidecar struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewSidecar returns a Sidecar pointed at baseURL (e.g.
+// "http://localhost:8000"), a spaCy/Stanza HTTP server exposing a POST
+// /ents endpoint. timeout of 0 means 5 seconds.
+func NewSidecar(baseURL string, timeout time.Duration) *Sidecar {
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	return &Sidecar{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// sidecarResponse is POST /ents' expected JSON shape — Query's fields,
+// lowercased.
+type sidecarResponse struct {
+	People   []string `json:"people"`
+	Places   []string `json:"places"`
+	Orgs     []string `json:"orgs"`
+	Dates    []string `json:"dates"`
+	Keywords []string `json:"keywords"`
+}
+
+// ExtractEntities calls s's sidecar's POST /ents with text, returning its
+// tagged entities as a Query. Callers wanting a fallback to the heuristic
+// ExtractEntities function on a sidecar error handle that themselves — see
+// visuals.ProofScraper.entitiesFor.
+func (s *Sidecar) ExtractEntities(ctx context.Context, text string) (Query, error) {
+	payload, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{Text: text})
+	if err != nil {
+		return Query{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.BaseURL+"/ents", bytes.NewReader(payload))
+	if err != nil {
+		return Query{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return Query{}, fmt.Errorf("nlp sidecar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Query{}, fmt.Errorf("nlp sidecar: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var parsed sidecarResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Query{}, fmt.Errorf("nlp sidecar: decode response: %w", err)
+	}
+	return Query{
+		People:   parsed.People,
+		Places:   parsed.Places,
+		Orgs:     parsed.Orgs,
+		Dates:    parsed.Dates,
+		Keywords: parsed.Keywords,
+	}, nil
+}
+
+// sentenceEnd marks the punctuation that resets capitalization-run
+// detection's "this word starts a new sentence" state.
+const sentenceEnd = ".!?"
+
+// trimPunct strips text's leading/trailing punctuation, returning the bare
+// word plus whichever sentence-ending punctuation (if any) trailed it.
+func trimPunct(w string) (clean string, trailingEnd string) {
+	w = strings.Trim(w, "\"'()[]")
+	trimmed := strings.TrimRight(w, sentenceEnd+",;:")
+	if len(trimmed) < len(w) && strings.ContainsAny(w[len(trimmed):], sentenceEnd) {
+		trailingEnd = w[len(trimmed):]
+	}
+	return trimmed, trailingEnd
+}
+
+func isCapitalized(w string) bool {
+	if w == "" {
+		return false
+	}
+	r := rune(w[0])
+	return r >= 'A' && r <= 'Z'
+}
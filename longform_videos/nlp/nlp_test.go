@@ -0,0 +1,109 @@
+package nlp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExtractEntitiesPeoplePlacesOrgsDates(t *testing.T) {
+	q := ExtractEntities("Ted Bundy was arrested by the Salt Lake City Police Department on August 16, 1975, near Lake Sammamish in Washington.")
+
+	if q.TopPerson() != "Ted Bundy" {
+		t.Errorf("TopPerson() = %q, want %q", q.TopPerson(), "Ted Bundy")
+	}
+	if !containsStr(q.Orgs, "Salt Lake City Police Department") {
+		t.Errorf("Orgs = %v, want it to contain the police department", q.Orgs)
+	}
+	if !containsStr(q.Dates, "August 16, 1975") {
+		t.Errorf("Dates = %v, want it to contain the arrest date", q.Dates)
+	}
+	if !containsStr(q.Places, "Washington") {
+		t.Errorf("Places = %v, want it to contain Washington", q.Places)
+	}
+}
+
+func TestExtractEntitiesIgnoresSentenceInitialCapital(t *testing.T) {
+	q := ExtractEntities("Detectives reopened the case this week.")
+	if len(q.People) != 0 {
+		t.Errorf("People = %v, want none — \"Detectives\" is just sentence-initial capitalization", q.People)
+	}
+}
+
+func TestExtractEntitiesFallsBackToKeywords(t *testing.T) {
+	q := ExtractEntities("nobody remembered anything about that night")
+	if len(q.People) != 0 || len(q.Places) != 0 || len(q.Orgs) != 0 {
+		t.Fatalf("expected no named entities, got People=%v Places=%v Orgs=%v", q.People, q.Places, q.Orgs)
+	}
+	if len(q.Keywords) == 0 {
+		t.Errorf("expected Keywords to be populated as a fallback")
+	}
+}
+
+func TestQueryTopPersonTopPlaceEmpty(t *testing.T) {
+	var q Query
+	if q.TopPerson() != "" {
+		t.Errorf("TopPerson() on empty Query = %q, want \"\"", q.TopPerson())
+	}
+	if q.TopPlace() != "" {
+		t.Errorf("TopPlace() on empty Query = %q, want \"\"", q.TopPlace())
+	}
+}
+
+func containsStr(list []string, want string) bool {
+	for _, s := range list {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSidecarExtractEntitiesParsesResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ents" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+		if body.Text != "Ted Bundy was arrested in Washington." {
+			t.Errorf("request text = %q", body.Text)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(sidecarResponse{
+			People: []string{"Ted Bundy"},
+			Places: []string{"Washington"},
+		})
+	}))
+	defer srv.Close()
+
+	sidecar := NewSidecar(srv.URL, 0)
+	q, err := sidecar.ExtractEntities(context.Background(), "Ted Bundy was arrested in Washington.")
+	if err != nil {
+		t.Fatalf("ExtractEntities: %v", err)
+	}
+	if q.TopPerson() != "Ted Bundy" {
+		t.Errorf("TopPerson() = %q, want %q", q.TopPerson(), "Ted Bundy")
+	}
+	if q.TopPlace() != "Washington" {
+		t.Errorf("TopPlace() = %q, want %q", q.TopPlace(), "Washington")
+	}
+}
+
+func TestSidecarExtractEntitiesErrorsOnNonOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "sidecar exploded", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	sidecar := NewSidecar(srv.URL, 0)
+	if _, err := sidecar.ExtractEntities(context.Background(), "anything"); err == nil {
+		t.Fatal("expected an error for a non-200 sidecar response")
+	}
+}
@@ -0,0 +1,243 @@
+// Package hls packages the final rendered MP4 into a multi-bitrate HLS
+// ladder (and, optionally, a DASH manifest) for CDN/preview delivery — an
+// addition to, not a replacement for, the single-file upload in the
+// 09_upload stage. Renditions, codec, and optional AES-128 encryption are
+// driven by config.UploadConfig.HLS.
+package hls
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"true-crime-pipeline/config"
+)
+
+// Packager renders an HLS rendition ladder from a finished video file.
+type Packager struct {
+	cfg *config.Config
+}
+
+// New creates a new Packager.
+func New(cfg *config.Config) *Packager {
+	return &Packager{cfg: cfg}
+}
+
+// Package invokes ffmpeg to transcode videoFile into the rendition ladder
+// configured under Upload.HLS.Renditions, writing segments and playlists
+// into outDir, and returns the path to the generated master playlist.
+//
+// Every segment filename carries a random 6-byte session prefix, so a CDN
+// can never be handed a stale segment left over from a previous run that
+// wrote into the same outDir. If Upload.HLS.Enabled is false, Package is a
+// no-op and returns "", nil.
+func (p *Packager) Package(ctx context.Context, videoFile, outDir string) (string, error) {
+	cfg := p.cfg.Upload.HLS
+	if !cfg.Enabled {
+		log.Println("[hls] HLS packaging disabled in config — skipping")
+		return "", nil
+	}
+	if len(cfg.Renditions) == 0 {
+		return "", fmt.Errorf("hls: Upload.HLS.Enabled is true but no Renditions configured")
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return "", err
+	}
+
+	prefix, err := randomHex(6)
+	if err != nil {
+		return "", fmt.Errorf("hls: generate session prefix: %w", err)
+	}
+
+	var keyInfoFile string
+	if cfg.Encrypt {
+		keyInfoFile, err = p.writeKeyInfo(outDir, prefix)
+		if err != nil {
+			return "", fmt.Errorf("hls: write key info: %w", err)
+		}
+	}
+
+	segmentType := cfg.SegmentType
+	if segmentType == "" {
+		segmentType = "mpegts"
+	}
+	segmentSec := cfg.SegmentSec
+	if segmentSec == 0 {
+		segmentSec = 6
+	}
+	codec := videoCodec(cfg.Codec)
+
+	log.Printf("[hls] Packaging %d rendition(s) (codec=%s, segment_type=%s, encrypt=%v)...", len(cfg.Renditions), codec, segmentType, cfg.Encrypt)
+
+	args := append([]string{"-y", "-i", videoFile}, p.encodeArgs(cfg, codec, segmentSec)...)
+	var varStreamMap []string
+	for i := range cfg.Renditions {
+		varStreamMap = append(varStreamMap, fmt.Sprintf("v:%d,a:%d", i, i))
+	}
+
+	// %v fans the pattern out per rendition — without it every bitrate
+	// would race to write the same segment numbers into one file.
+	segmentFilename := filepath.Join(outDir, fmt.Sprintf("%s_%%v_%%05d.%s", prefix, segmentExt(segmentType)))
+	args = append(args,
+		"-f", "hls",
+		"-hls_time", fmt.Sprint(segmentSec),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_type", segmentType,
+		"-var_stream_map", strings.Join(varStreamMap, " "),
+		"-master_pl_name", "master.m3u8",
+		"-hls_segment_filename", segmentFilename,
+	)
+	if keyInfoFile != "" {
+		args = append(args, "-hls_key_info_file", keyInfoFile)
+	}
+	args = append(args, filepath.Join(outDir, "stream_%v.m3u8"))
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ffmpeg hls package: %w", err)
+	}
+
+	playlist := filepath.Join(outDir, "master.m3u8")
+	log.Printf("[hls] ✅ HLS ladder ready: %s", playlist)
+
+	if cfg.DASH {
+		// A DASH failure (e.g. ffmpeg's build lacks the dash muxer) doesn't
+		// invalidate the HLS ladder already written above — log and return
+		// the HLS playlist rather than discarding a successful pass.
+		if err := p.packageDASH(ctx, videoFile, outDir, prefix, codec, segmentSec); err != nil {
+			log.Printf("[hls] Warning: DASH packaging failed: %v — HLS ladder is still ready", err)
+		}
+	}
+
+	return playlist, nil
+}
+
+// packageDASH runs a second ffmpeg pass over the same rendition ladder to
+// produce a DASH manifest (dash.mpd) alongside the HLS playlists, for
+// players that prefer MPEG-DASH. DASH doesn't support segment encryption
+// the way HLS's -hls_key_info_file does, so this ignores Encrypt. Segment
+// and init filenames carry the same per-run prefix as the HLS pass, for
+// the same reason: a CDN must never be handed a stale segment left over
+// from a previous run into the same outDir.
+func (p *Packager) packageDASH(ctx context.Context, videoFile, outDir, prefix, codec string, segmentSec int) error {
+	cfg := p.cfg.Upload.HLS
+	args := append([]string{"-y", "-i", videoFile}, p.encodeArgs(cfg, codec, segmentSec)...)
+	args = append(args,
+		"-f", "dash",
+		"-seg_duration", fmt.Sprint(segmentSec),
+		"-use_template", "1",
+		"-use_timeline", "1",
+		"-init_seg_name", prefix+"_init_$RepresentationID$.$ext$",
+		"-media_seg_name", prefix+"_chunk_$RepresentationID$_$Number%05d$.$ext$",
+		filepath.Join(outDir, "dash.mpd"),
+	)
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg dash package: %w", err)
+	}
+	log.Printf("[hls] ✅ DASH manifest ready: %s", filepath.Join(outDir, "dash.mpd"))
+	return nil
+}
+
+// encodeArgs builds the -map/-c:v/-b:v/... stream-mapping args shared by
+// both the HLS and DASH passes: one video+audio rendition per configured
+// rung, encoded with codec, plus aligned keyframes every segmentSec seconds
+// (assuming a 24fps source) so each rendition can be segmented at the same
+// boundaries regardless of scene-cut detection.
+func (p *Packager) encodeArgs(cfg config.HLSConfig, codec string, segmentSec int) []string {
+	gop := segmentSec * 24
+	args := []string{
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentSec),
+		"-g", fmt.Sprint(gop),
+		"-keyint_min", fmt.Sprint(gop),
+		"-sc_threshold", "0",
+	}
+	for i, r := range cfg.Renditions {
+		args = append(args,
+			"-map", "0:v:0", "-map", "0:a:0",
+			fmt.Sprintf("-c:v:%d", i), codec,
+			fmt.Sprintf("-b:v:%d", i), r.VideoBitrate,
+			fmt.Sprintf("-s:v:%d", i), fmt.Sprintf("-2:%d", r.Height),
+			fmt.Sprintf("-c:a:%d", i), "aac",
+			fmt.Sprintf("-b:a:%d", i), r.AudioBitrate,
+		)
+	}
+	return args
+}
+
+// videoCodec maps HLSConfig.Codec to its ffmpeg encoder name; "" (and any
+// unrecognized value) defaults to H.264/libx264.
+func videoCodec(codec string) string {
+	switch strings.ToLower(codec) {
+	case "hevc", "h265":
+		return "libx265"
+	case "av1":
+		return "libsvtav1"
+	default:
+		return "libx264"
+	}
+}
+
+// writeKeyInfo generates a random AES-128 key + IV for this run, writes the
+// key to outDir/enc.key, and writes the .keyinfo file ffmpeg's
+// -hls_key_info_file expects (keyURI, keyFile, IV — one per line).
+func (p *Packager) writeKeyInfo(outDir, prefix string) (string, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	keyFile := filepath.Join(outDir, "enc.key")
+	if err := os.WriteFile(keyFile, key, 0600); err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, 16)
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	keyURI := p.cfg.Upload.HLS.KeyURITemplate
+	if keyURI == "" {
+		keyURI = "enc.key"
+	} else {
+		keyURI = strings.ReplaceAll(keyURI, "{prefix}", prefix)
+	}
+
+	keyInfoFile := filepath.Join(outDir, prefix+".keyinfo")
+	contents := fmt.Sprintf("%s\n%s\n%s\n", keyURI, keyFile, hex.EncodeToString(iv))
+	if err := os.WriteFile(keyInfoFile, []byte(contents), 0644); err != nil {
+		return "", err
+	}
+	return keyInfoFile, nil
+}
+
+// segmentExt returns the file extension ffmpeg uses for a given
+// -hls_segment_type: "ts" for mpegts, "m4s" for fmp4.
+func segmentExt(segmentType string) string {
+	if segmentType == "fmp4" {
+		return "m4s"
+	}
+	return "ts"
+}
+
+// randomHex returns a random n-byte value hex-encoded, used as the
+// per-run session prefix on every segment filename.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
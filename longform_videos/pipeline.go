@@ -3,22 +3,31 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"hash/fnv"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"true-crime-pipeline/01_research"
 	"true-crime-pipeline/02_script"
 	"true-crime-pipeline/03_audio"
 	"true-crime-pipeline/04_visuals"
+	"true-crime-pipeline/04_visuals/ingest"
 	"true-crime-pipeline/05_subtitles"
 	"true-crime-pipeline/06_sfx"
 	"true-crime-pipeline/07_render"
 	"true-crime-pipeline/08_metadata"
 	"true-crime-pipeline/09_upload"
+	"true-crime-pipeline/cache"
 	"true-crime-pipeline/config"
+	"true-crime-pipeline/media"
+	"true-crime-pipeline/media/validate"
+	"true-crime-pipeline/packaging/hls"
+	"true-crime-pipeline/progress"
 	"true-crime-pipeline/types"
 
 	"github.com/google/uuid"
@@ -35,6 +44,21 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		runImport(cfg, os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "ingest" {
+		runIngest(cfg, os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "upload-resume" {
+		runUploadResume(cfg, os.Args[2:])
+		return
+	}
+
 	// Ensure required dirs exist
 	for _, dir := range []string{cfg.Paths.Output, cfg.Paths.Logs, cfg.Paths.AssetsVideo, cfg.Paths.AssetsSFX} {
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -42,17 +66,38 @@ func main() {
 		}
 	}
 
-	// Create run ID and output dir for this run
-	runID := uuid.NewString()[:8]
-	runDir := filepath.Join(cfg.Paths.Output, runID)
-	if err := os.MkdirAll(runDir, 0755); err != nil {
-		log.Fatalf("Failed to create run dir: %v", err)
+	fs := flag.NewFlagSet("pipeline", flag.ExitOnError)
+	resumeRunID := fs.String("resume", "", "resume an existing run ID instead of starting a fresh one, reusing its runDir/cache/ so unchanged stages are restored instead of recomputed")
+	fromStage := fs.Int("from-stage", 1, "skip stages before this number (1=Research, 2=Script, 3=Audio, 4=Visuals) without even checking the cache, trusting runDir's existing output; requires -resume")
+	_ = fs.Parse(os.Args[1:])
+	if *fromStage > 1 && *resumeRunID == "" {
+		log.Fatalf("-from-stage requires -resume <runID>")
 	}
 
-	log.Printf("🎬 TrueCrime Pipeline starting — Run ID: %s", runID)
+	// Create (or reuse, via -resume) the run ID and output dir for this run
+	var runID string
+	if *resumeRunID != "" {
+		runID = *resumeRunID
+	} else {
+		runID = uuid.NewString()[:8]
+	}
+	runDir := filepath.Join(cfg.Paths.Output, runID)
+	if *resumeRunID != "" {
+		if _, err := os.Stat(runDir); err != nil {
+			log.Fatalf("-resume %s: run dir %s not found: %v", runID, runDir, err)
+		}
+		log.Printf("🎬 TrueCrime Pipeline resuming — Run ID: %s", runID)
+	} else {
+		if err := os.MkdirAll(runDir, 0755); err != nil {
+			log.Fatalf("Failed to create run dir: %v", err)
+		}
+		log.Printf("🎬 TrueCrime Pipeline starting — Run ID: %s", runID)
+	}
+	store := cache.NewStore(runDir)
 	log.Printf("📁 Output dir: %s", runDir)
 
 	ctx := context.Background()
+	reporter := progress.NewCLIReporter()
 	state := &types.PipelineState{
 		RunID:     runID,
 		StartedAt: time.Now().UTC().Format(time.RFC3339),
@@ -73,38 +118,85 @@ func main() {
 	// STAGE 1: Research
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 1: Research ━━━")
-	scraper := research.New(cfg)
-	story, err := scraper.Run(ctx)
+	story := &types.Story{}
+	storyPath := filepath.Join(runDir, "story.json")
+	err = runCachedStage(state, store, "research", cfg.Research, storyPath, *fromStage > 1, func() error {
+		scraper := research.New(cfg)
+		s, err := scraper.Run(ctx)
+		if err != nil {
+			return err
+		}
+		saveJSON(storyPath, s)
+		return nil
+	})
 	if err != nil {
 		state.Error = fmt.Sprintf("Stage 1 Research: %v", err)
 		return
 	}
+	if err := loadJSON(storyPath, story); err != nil {
+		state.Error = fmt.Sprintf("Stage 1 Research: load story: %v", err)
+		return
+	}
 	state.Story = story
-	saveJSON(filepath.Join(runDir, "story.json"), story)
 
 	// ─────────────────────────────────────────────
 	// STAGE 2: Script Writing
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 2: Script Writing ━━━")
-	writer := script.New(cfg)
-	scriptData, err := writer.Run(ctx, story)
+	scriptData := &types.Script{}
+	scriptPath := filepath.Join(runDir, "script.json")
+	scriptHashInput := struct {
+		Story  *types.Story
+		Script config.ScriptConfig
+	}{story, cfg.Script}
+	err = runCachedStage(state, store, "script", scriptHashInput, scriptPath, *fromStage > 2, func() error {
+		writer := script.New(cfg)
+		s, err := writer.Run(ctx, story, "")
+		if err != nil {
+			return err
+		}
+		saveJSON(scriptPath, s)
+		return nil
+	})
 	if err != nil {
 		state.Error = fmt.Sprintf("Stage 2 Script: %v", err)
 		return
 	}
+	if err := loadJSON(scriptPath, scriptData); err != nil {
+		state.Error = fmt.Sprintf("Stage 2 Script: load script: %v", err)
+		return
+	}
 	state.Script = scriptData
-	saveJSON(filepath.Join(runDir, "script.json"), scriptData)
 
 	// ─────────────────────────────────────────────
 	// STAGE 3: Audio Generation
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 3: Audio Generation ━━━")
 	audioDir := filepath.Join(runDir, "audio")
-	audioGen := audio.New(cfg)
-	if err := audioGen.Run(ctx, scriptData, audioDir); err != nil {
+	audioSnapshot := filepath.Join(audioDir, "script_snapshot.json")
+	audioHashInput := struct {
+		Script *types.Script
+		Audio  config.AudioConfig
+	}{scriptData, cfg.Audio}
+	err = runCachedStage(state, store, "audio", audioHashInput, audioDir, *fromStage > 3, func() error {
+		audioGen := audio.New(cfg, reporter)
+		if err := audioGen.Run(ctx, scriptData, audioDir); err != nil {
+			return err
+		}
+		// scriptData picked up per-scene audio durations/word timings from
+		// Run above; snapshot it alongside the audio so a cache hit restores
+		// those along with the rendered files.
+		saveJSON(audioSnapshot, scriptData)
+		return nil
+	})
+	if err != nil {
 		state.Error = fmt.Sprintf("Stage 3 Audio: %v", err)
 		return
 	}
+	if err := loadJSON(audioSnapshot, scriptData); err != nil {
+		state.Error = fmt.Sprintf("Stage 3 Audio: load script snapshot: %v", err)
+		return
+	}
 	finalAudio := filepath.Join(audioDir, "audio_final.mp3")
 	state.AudioFile = finalAudio
 	// Re-save script with updated audio timestamps
@@ -115,15 +207,32 @@ func main() {
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 4: Visuals ━━━")
 	visualDir := filepath.Join(runDir, "visuals")
+	visualSnapshot := filepath.Join(visualDir, "script_snapshot.json")
 	assembler, err := visuals.NewAssembler(cfg, runID)
 	if err != nil {
 		state.Error = fmt.Sprintf("Stage 4 Visuals init: %v", err)
 		return
 	}
-	if err := assembler.Run(ctx, scriptData, story, visualDir); err != nil {
+	defer assembler.Close()
+	visualHashInput := struct {
+		Script  *types.Script
+		Visuals config.VisualsConfig
+	}{scriptData, cfg.Visuals}
+	err = runCachedStage(state, store, "visuals", visualHashInput, visualDir, *fromStage > 4, func() error {
+		if err := assembler.Run(ctx, scriptData, story, visualDir); err != nil {
+			return err
+		}
+		saveJSON(visualSnapshot, scriptData)
+		return nil
+	})
+	if err != nil {
 		state.Error = fmt.Sprintf("Stage 4 Visuals: %v", err)
 		return
 	}
+	if err := loadJSON(visualSnapshot, scriptData); err != nil {
+		state.Error = fmt.Sprintf("Stage 4 Visuals: load script snapshot: %v", err)
+		return
+	}
 	// Re-save script with visual file paths
 	saveJSON(filepath.Join(runDir, "script.json"), scriptData)
 
@@ -132,8 +241,8 @@ func main() {
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 5: Subtitles ━━━")
 	subtitleDir := filepath.Join(runDir, "subtitles")
-	subGen := subtitles.New(cfg)
-	srtFile, err := subGen.Run(ctx, finalAudio, subtitleDir)
+	subGen := subtitles.New(cfg, reporter)
+	srtFile, err := subGen.Run(ctx, scriptData, finalAudio, subtitleDir)
 	if err != nil {
 		log.Printf("⚠️  Stage 5 Subtitles failed: %v — continuing without subtitles", err)
 		srtFile = ""
@@ -143,9 +252,27 @@ func main() {
 	// STAGE 6: SFX
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 6: SFX Matching ━━━")
-	sfxMatcher := sfx.New(cfg)
+	sfxMatcher := sfx.New(cfg, reporter)
+	mixedAudio := finalAudio
 	if err := sfxMatcher.Run(ctx, scriptData, runDir); err != nil {
 		log.Printf("⚠️  Stage 6 SFX failed: %v — continuing without SFX", err)
+	} else {
+		musicBed := ""
+		if bed, err := sfxMatcher.BuildMusicBed(ctx, scriptData, runDir); err != nil {
+			log.Printf("⚠️  Stage 6 music bed build failed: %v — continuing without music", err)
+		} else {
+			musicBed = bed
+		}
+		if mixed, err := sfxMatcher.MixWithNarration(ctx, scriptData, finalAudio, musicBed, runDir); err != nil {
+			log.Printf("⚠️  Stage 6 SFX ducking mix failed: %v — using narration only", err)
+		} else {
+			mixedAudio = mixed
+		}
+	}
+	if normalized, err := sfxMatcher.NormalizeLoudness(ctx, mixedAudio, runDir); err != nil {
+		log.Printf("⚠️  Loudness normalization failed: %v — using unnormalized mix", err)
+	} else {
+		mixedAudio = normalized
 	}
 
 	// ─────────────────────────────────────────────
@@ -153,7 +280,7 @@ func main() {
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 7: Rendering ━━━")
 	renderer := render.New(cfg)
-	finalVideo, err := renderer.Run(ctx, scriptData, finalAudio, runDir)
+	finalVideo, err := renderer.Run(ctx, scriptData, mixedAudio, runDir)
 	if err != nil {
 		state.Error = fmt.Sprintf("Stage 7 Render: %v", err)
 		return
@@ -171,12 +298,32 @@ func main() {
 		}
 	}
 
+	// ─────────────────────────────────────────────
+	// STAGE 7c: Media Validation
+	// ─────────────────────────────────────────────
+	if err := validate.FinalVideo(ctx, media.New(runDir), finalVideo, scriptData.TotalSec, finalDurationTolerance(cfg)); err != nil {
+		state.Error = fmt.Sprintf("Stage 7c Media Validation: %v", err)
+		return
+	}
+
+	// ─────────────────────────────────────────────
+	// STAGE 7b: HLS Packaging (optional, alongside the YouTube upload)
+	// ─────────────────────────────────────────────
+	packager := hls.New(cfg)
+	hlsDir := filepath.Join(runDir, "hls")
+	playlist, err := packager.Package(ctx, finalVideo, hlsDir)
+	if err != nil {
+		log.Printf("⚠️  HLS packaging failed: %v — continuing without it", err)
+	} else if playlist != "" {
+		state.HLSPlaylist = playlist
+	}
+
 	// ─────────────────────────────────────────────
 	// STAGE 8: Metadata
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 8: Metadata Generation ━━━")
 	metaGen := metadata.New(cfg)
-	videoMetadata, err := metaGen.Run(ctx, scriptData, story)
+	videoMetadata, err := metaGen.Run(ctx, scriptData, story, "")
 	if err != nil {
 		state.Error = fmt.Sprintf("Stage 8 Metadata: %v", err)
 		return
@@ -188,7 +335,7 @@ func main() {
 	// STAGE 9: Upload
 	// ─────────────────────────────────────────────
 	log.Println("\n━━━ STAGE 9: YouTube Upload ━━━")
-	uploader := upload.New(cfg)
+	uploader := upload.New(cfg, runID)
 	videoID, videoURL, err := uploader.Run(ctx, finalVideo, videoMetadata)
 	if err != nil {
 		state.Error = fmt.Sprintf("Stage 9 Upload: %v", err)
@@ -199,6 +346,395 @@ func main() {
 
 	// Log upload
 	_ = upload.LogUpload(videoID, videoURL, finalVideo, cfg.Paths.Logs, videoMetadata)
+
+	// ─────────────────────────────────────────────
+	// STAGE 9a: Thumbnail
+	// ─────────────────────────────────────────────
+	if cfg.Visuals.Thumbnail.Enabled {
+		log.Println("\n━━━ STAGE 9a: Thumbnail Generation ━━━")
+		if err := generateAndSetThumbnail(ctx, cfg, uploader, videoMetadata, state, filepath.Join(runDir, "thumbnails")); err != nil {
+			log.Printf("⚠️  Thumbnail generation failed: %v — keeping YouTube's auto-picked thumbnail", err)
+		}
+	}
+
+	// ─────────────────────────────────────────────
+	// STAGE 9b: Localized Variants
+	// ─────────────────────────────────────────────
+	if len(cfg.Localization.Languages) > 0 {
+		log.Println("\n━━━ STAGE 9b: Localized Variants ━━━")
+		for _, lang := range cfg.Localization.Languages {
+			variant := runLocalizedVariant(ctx, cfg, reporter, story, scriptData, runID, runDir, lang)
+			state.Variants = append(state.Variants, variant)
+			if variant.Error != "" {
+				log.Printf("⚠️  Localized variant %q failed: %s — continuing with remaining languages", lang, variant.Error)
+				continue
+			}
+			if err := uploader.SetLocalization(ctx, state.YouTubeID, lang, variant.Metadata); err != nil {
+				log.Printf("⚠️  [%s] Localizations update on master video failed: %v", lang, err)
+			}
+		}
+	}
+}
+
+// ─────────────────────────────────────────────
+// `import` subcommand
+// ─────────────────────────────────────────────
+
+// runImport implements `pipeline import <path>`: seed a story from a
+// static archive via research.ArchiveImporter instead of running STAGE 1
+// against live Reddit/NewsAPI/RSS sources, so the module works offline for
+// testing or a curated content playlist. It prints the winning story as
+// JSON to stdout, the same shape research.Scraper.Run returns.
+func runImport(cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		log.Fatalf("usage: pipeline import <path>")
+	}
+
+	importer := research.NewArchiveImporter(cfg)
+	story, err := importer.Import(args[0])
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	data, err := json.MarshalIndent(story, "", "  ")
+	if err != nil {
+		log.Fatalf("Marshal imported story: %v", err)
+	}
+	fmt.Println(string(data))
+}
+
+// ─────────────────────────────────────────────
+// `ingest` subcommand
+// ─────────────────────────────────────────────
+
+// runIngest implements `pipeline ingest -queries <path> -n <count>`: backfill
+// AssetManager's video asset library from a newline-separated list of
+// B-roll search queries via visuals/ingest, so sourcing new clips doesn't
+// require a manual yt-dlp-then-tag-by-hand pass.
+func runIngest(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("ingest", flag.ExitOnError)
+	queriesPath := fs.String("queries", "", "path to a newline-separated file of B-roll search queries")
+	count := fs.Int("n", 50, "number of new clips to ingest across all queries")
+	fs.Parse(args)
+
+	if *queriesPath == "" {
+		log.Fatalf("usage: pipeline ingest -queries <path> [-n 50]")
+	}
+
+	queries, err := loadQueries(*queriesPath)
+	if err != nil {
+		log.Fatalf("Load queries: %v", err)
+	}
+
+	ingester := ingest.New(cfg)
+	if err := ingester.Run(context.Background(), queries, *count); err != nil {
+		log.Fatalf("Ingest failed: %v", err)
+	}
+}
+
+// loadQueries reads one search query per non-blank line of path.
+func loadQueries(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var queries []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			queries = append(queries, line)
+		}
+	}
+	return queries, nil
+}
+
+// ─────────────────────────────────────────────
+// Localization
+// ─────────────────────────────────────────────
+
+// runLocalizedVariant regenerates one Localization.Languages entry end to
+// end: its own narration (a fresh script.Writer.Run call), its own
+// audio/subtitles/render, a translated metadata.Generator.Run, and its
+// own upload — reusing primaryScript's STAGE 4 visual assets scene-for-
+// scene via reuseVisualAssets rather than re-running the Assembler, since
+// image prompts and asset tags don't need translation. Any stage failure
+// is recorded on the returned VariantState instead of aborting main(), so
+// one broken language doesn't take the others down with it.
+func runLocalizedVariant(ctx context.Context, cfg *config.Config, reporter progress.Reporter, story *types.Story, primaryScript *types.Script, runID, runDir, lang string) types.VariantState {
+	variant := types.VariantState{Language: lang}
+	variantDir := filepath.Join(runDir, "lang_"+lang)
+
+	log.Printf("[%s] Generating script...", lang)
+	localScript, err := script.New(cfg).Run(ctx, story, lang)
+	if err != nil {
+		variant.Error = fmt.Sprintf("script: %v", err)
+		return variant
+	}
+	reuseVisualAssets(primaryScript, localScript)
+	variant.Script = localScript
+
+	log.Printf("[%s] Generating audio...", lang)
+	audioDir := filepath.Join(variantDir, "audio")
+	if err := audio.New(cfg, reporter).Run(ctx, localScript, audioDir); err != nil {
+		variant.Error = fmt.Sprintf("audio: %v", err)
+		return variant
+	}
+	finalAudio := filepath.Join(audioDir, "audio_final.mp3")
+	variant.AudioFile = finalAudio
+	warnIfDurationDiverges(primaryScript, localScript, lang)
+
+	sfxMatcher := sfx.New(cfg, reporter)
+	mixedAudio := finalAudio
+	if err := sfxMatcher.Run(ctx, localScript, variantDir); err != nil {
+		log.Printf("⚠️  [%s] SFX failed: %v — continuing without SFX", lang, err)
+	} else {
+		musicBed := ""
+		if bed, err := sfxMatcher.BuildMusicBed(ctx, localScript, variantDir); err != nil {
+			log.Printf("⚠️  [%s] music bed build failed: %v — continuing without music", lang, err)
+		} else {
+			musicBed = bed
+		}
+		if mixed, err := sfxMatcher.MixWithNarration(ctx, localScript, finalAudio, musicBed, variantDir); err != nil {
+			log.Printf("⚠️  [%s] SFX ducking mix failed: %v — using narration only", lang, err)
+		} else {
+			mixedAudio = mixed
+		}
+	}
+	if normalized, err := sfxMatcher.NormalizeLoudness(ctx, mixedAudio, variantDir); err != nil {
+		log.Printf("⚠️  [%s] Loudness normalization failed: %v — using unnormalized mix", lang, err)
+	} else {
+		mixedAudio = normalized
+	}
+
+	// Subtitles.DualLanguage translates the primary run's narration into one
+	// fixed target language — meaningless (and confusing) stacked onto a
+	// variant whose narration is already dubbed into a different language —
+	// so it's switched off for every localized variant.
+	variantCfg := *cfg
+	variantCfg.Subtitles.DualLanguage.Enabled = false
+	subGen := subtitles.New(&variantCfg, reporter)
+	srtFile, err := subGen.Run(ctx, localScript, finalAudio, filepath.Join(variantDir, "subtitles"))
+	if err != nil {
+		log.Printf("⚠️  [%s] Subtitles failed: %v — continuing without subtitles", lang, err)
+		srtFile = ""
+	}
+	variant.SRTFile = srtFile
+
+	log.Printf("[%s] Rendering...", lang)
+	finalVideo, err := render.New(cfg).Run(ctx, localScript, mixedAudio, variantDir)
+	if err != nil {
+		variant.Error = fmt.Sprintf("render: %v", err)
+		return variant
+	}
+	if srtFile != "" {
+		if subtitledVideo, err := subGen.BurnIntoVideo(ctx, finalVideo, srtFile, variantDir); err != nil {
+			log.Printf("⚠️  [%s] Subtitle burn failed: %v — using video without subtitles", lang, err)
+		} else {
+			finalVideo = subtitledVideo
+		}
+	}
+	if err := validate.FinalVideo(ctx, media.New(variantDir), finalVideo, localScript.TotalSec, finalDurationTolerance(cfg)); err != nil {
+		variant.Error = fmt.Sprintf("media validation: %v", err)
+		return variant
+	}
+	variant.VideoFile = finalVideo
+
+	variantMetadata, err := metadata.New(cfg).Run(ctx, localScript, story, lang)
+	if err != nil {
+		variant.Error = fmt.Sprintf("metadata: %v", err)
+		return variant
+	}
+	variant.Metadata = variantMetadata
+
+	log.Printf("[%s] Uploading...", lang)
+	videoID, videoURL, err := upload.New(cfg, runID+"_"+lang).Run(ctx, finalVideo, variantMetadata)
+	if err != nil {
+		variant.Error = fmt.Sprintf("upload: %v", err)
+		return variant
+	}
+	variant.YouTubeID = videoID
+	variant.YouTubeURL = videoURL
+	_ = upload.LogUpload(videoID, videoURL, finalVideo, cfg.Paths.Logs, variantMetadata)
+
+	return variant
+}
+
+// warnIfDurationDiverges flags scenes whose localized narration runs
+// noticeably longer or shorter than the primary script's — the clip
+// reuseVisualAssets copied over was cut to the primary's own
+// AudioDurationSec (see visuals.Assembler's Ken Burns/clip-trim pass), so
+// a big divergence means render.Renderer.combineVideoAudio's -shortest
+// join will cut the localized video off early or leave it frozen on the
+// last frame. This only logs — fixing it for real means either re-running
+// STAGE 4 per language (the cost Localization is meant to avoid) or a
+// validation/fallback gate, which is out of scope here.
+func warnIfDurationDiverges(primary, secondary *types.Script, lang string) {
+	n := len(primary.Scenes)
+	if len(secondary.Scenes) < n {
+		n = len(secondary.Scenes)
+	}
+	for i := 0; i < n; i++ {
+		p, s := primary.Scenes[i].AudioDurationSec, secondary.Scenes[i].AudioDurationSec
+		if p <= 0 {
+			continue
+		}
+		if diff := (s - p) / p; diff > 0.15 || diff < -0.15 {
+			log.Printf("⚠️  [%s] Scene %d narration is %.0f%% %s than the reused visual clip's length (%.1fs vs %.1fs) — audio/video may drift out of sync", lang, i, abs(diff)*100, longerOrShorter(diff), s, p)
+		}
+	}
+}
+
+// generateAndSetThumbnail builds A/B thumbnail candidates via
+// visuals.ThumbnailGenerator off videoMetadata.ThumbnailPrompt and its
+// TitleVariants, attaches one to the already-uploaded video via
+// uploader.SetThumbnail — Thumbnail.WinnerIndex if set, else an
+// auto-rotated pick (see pickThumbnailIndex) — and records every candidate
+// plus the chosen one on state so a later analytics pass can rotate in one
+// of the others. A missing custom thumbnail isn't fatal — the upload
+// itself already succeeded — so every failure here is returned as a plain
+// error for the caller to log and continue past, not a pipeline abort.
+func generateAndSetThumbnail(ctx context.Context, cfg *config.Config, uploader *upload.Uploader, videoMetadata *types.VideoMetadata, state *types.PipelineState, thumbDir string) error {
+	pollinations := visuals.NewPollinationsFetcher(cfg)
+	thumbGen, err := visuals.NewThumbnailGenerator(cfg, pollinations)
+	if err != nil {
+		return fmt.Errorf("init thumbnail generator: %w", err)
+	}
+
+	titles := videoMetadata.TitleVariants
+	if len(titles) == 0 {
+		titles = []string{videoMetadata.Title}
+	}
+	candidates, err := thumbGen.Candidates(ctx, videoMetadata.ThumbnailPrompt, titles, thumbDir)
+	if err != nil {
+		return fmt.Errorf("generate thumbnail candidates: %w", err)
+	}
+	paths := make([]string, len(candidates))
+	for i, c := range candidates {
+		paths[i] = c.Path
+	}
+	state.ThumbnailCandidates = paths
+
+	chosen := pickThumbnail(cfg, state.RunID, candidates)
+	if err := uploader.SetThumbnail(ctx, state.YouTubeID, chosen); err != nil {
+		return fmt.Errorf("set thumbnail: %w", err)
+	}
+	state.ThumbnailChosen = chosen
+	return nil
+}
+
+// pickThumbnail returns which candidate's path to attach: the one whose
+// stable Index matches Thumbnail.WinnerIndex (1-based) if that style's
+// candidate survived generation, else an auto-rotated pick derived from
+// hashing runID, so repeated runs spread across candidates instead of
+// always attaching the first one. WinnerIndex names a style/seed, not a
+// position, so it still resolves correctly when an earlier candidate
+// failed and was dropped from the (already-compacted) candidates slice.
+func pickThumbnail(cfg *config.Config, runID string, candidates []visuals.ThumbnailCandidate) string {
+	if w := cfg.Visuals.Thumbnail.WinnerIndex; w >= 1 {
+		for _, c := range candidates {
+			if c.Index == w-1 {
+				return c.Path
+			}
+		}
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(runID))
+	return candidates[int(h.Sum32()%uint32(len(candidates)))].Path
+}
+
+// finalDurationTolerance is how far the assembled video's total duration
+// may drift from the script's TotalSec before validate.FinalVideo rejects
+// it; Validation.FinalDurationToleranceSec == 0 means the default 2s.
+func finalDurationTolerance(cfg *config.Config) float64 {
+	if cfg.Validation.FinalDurationToleranceSec > 0 {
+		return cfg.Validation.FinalDurationToleranceSec
+	}
+	return 2.0
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+func longerOrShorter(diff float64) string {
+	if diff > 0 {
+		return "longer"
+	}
+	return "shorter"
+}
+
+// reuseVisualAssets copies primary's scene-indexed visual fields — the
+// ones STAGE 4 populated: asset tags, image prompt, and the resulting
+// downloaded/generated file — onto secondary, scene-for-scene, so a
+// localized variant's narration doesn't trigger its own image generation
+// or asset matching pass. A scene-count mismatch between the two
+// independently-generated scripts truncates to the shorter of the two and
+// logs a warning rather than failing the variant.
+func reuseVisualAssets(primary, secondary *types.Script) {
+	n := len(primary.Scenes)
+	if len(secondary.Scenes) < n {
+		n = len(secondary.Scenes)
+	}
+	if len(primary.Scenes) != len(secondary.Scenes) {
+		log.Printf("⚠️  Localized script has %d scenes vs primary's %d — reusing visual assets for the first %d only", len(secondary.Scenes), len(primary.Scenes), n)
+	}
+	for i := 0; i < n; i++ {
+		secondary.Scenes[i].SceneType = primary.Scenes[i].SceneType
+		secondary.Scenes[i].ImagePrompt = primary.Scenes[i].ImagePrompt
+		secondary.Scenes[i].AssetTags = primary.Scenes[i].AssetTags
+		secondary.Scenes[i].ProofImageURL = primary.Scenes[i].ProofImageURL
+		secondary.Scenes[i].ProofImageLocal = primary.Scenes[i].ProofImageLocal
+		secondary.Scenes[i].ProofDurationSec = primary.Scenes[i].ProofDurationSec
+		secondary.Scenes[i].VisualFile = primary.Scenes[i].VisualFile
+	}
+}
+
+// ─────────────────────────────────────────────
+// `upload-resume` subcommand
+// ─────────────────────────────────────────────
+
+// runUploadResume implements `pipeline upload-resume <runID>`: re-drives
+// STAGE 9 for a run that crashed or was killed mid-upload. upload.Uploader
+// checkpoints resumable-upload progress to logs/upload_resume_<runID>.json
+// keyed by runID, but a fresh `pipeline` invocation always mints its own
+// runID — without this subcommand that checkpoint could never be found
+// again, and every restart would silently re-upload from byte zero.
+func runUploadResume(cfg *config.Config, args []string) {
+	if len(args) != 1 {
+		log.Fatalf("usage: pipeline upload-resume <runID>")
+	}
+	runID := args[0]
+
+	runDir := filepath.Join(cfg.Paths.Output, runID)
+	var state types.PipelineState
+	statePath := filepath.Join(runDir, "pipeline_state.json")
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		log.Fatalf("Read %s: %v", statePath, err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Fatalf("Parse %s: %v", statePath, err)
+	}
+	if state.VideoFile == "" || state.Metadata == nil {
+		log.Fatalf("%s has no rendered video/metadata to resume uploading", statePath)
+	}
+
+	log.Printf("[upload-resume] Resuming run %s: %s", runID, state.VideoFile)
+	uploader := upload.New(cfg, runID)
+	videoID, videoURL, err := uploader.Run(context.Background(), state.VideoFile, state.Metadata)
+	if err != nil {
+		log.Fatalf("Upload failed: %v", err)
+	}
+
+	state.YouTubeID = videoID
+	state.YouTubeURL = videoURL
+	saveState(&state, runDir)
+	_ = upload.LogUpload(videoID, videoURL, state.VideoFile, cfg.Paths.Logs, state.Metadata)
+
+	log.Printf("[upload-resume] ✅ Uploaded successfully! Video: %s", videoURL)
 }
 
 // ─────────────────────────────────────────────
@@ -209,6 +745,74 @@ func saveState(state *types.PipelineState, dir string) {
 	saveJSON(filepath.Join(dir, "pipeline_state.json"), state)
 }
 
+// runCachedStage runs one cache-eligible stage: fn must perform the stage's
+// real work and leave its full output (everything a later stage or a cache
+// hit needs) at outputPath, a file or directory. Before calling fn, it
+// checks store for an artifact keyed by a hash of (name, input); a hit
+// restores outputPath instead of calling fn. Either way a types.StageRecord
+// is appended to state.Stages. forceSkip (set via -from-stage) bypasses the
+// hash check entirely and trusts whatever is already at outputPath.
+func runCachedStage(state *types.PipelineState, store *cache.Store, name string, input interface{}, outputPath string, forceSkip bool, fn func() error) error {
+	hash, err := cache.Key(struct {
+		Stage string
+		Input interface{}
+	}{name, input})
+	if err != nil {
+		return fmt.Errorf("hash %s input: %w", name, err)
+	}
+
+	if forceSkip {
+		if _, err := os.Stat(outputPath); err != nil {
+			return fmt.Errorf("-from-stage requested skipping %q but %s doesn't exist: %w", name, outputPath, err)
+		}
+		log.Printf("[cache] ⏭️  %s skipped via -from-stage (trusting existing %s)", name, outputPath)
+		state.Stages = append(state.Stages, types.StageRecord{Name: name, InputHash: hash, OutputPath: outputPath, Cached: true})
+		return nil
+	}
+
+	start := time.Now()
+	cached := false
+	if store.Has(hash) {
+		if err := store.Restore(hash, outputPath); err != nil {
+			log.Printf("[cache] Warning: restore %s from cache failed: %v — recomputing", name, err)
+		} else {
+			cached = true
+			log.Printf("[cache] ✅ %s served from cache (%s)", name, hash[:12])
+		}
+	}
+	if !cached {
+		if err := fn(); err != nil {
+			return err
+		}
+		if err := store.Put(hash, name, outputPath); err != nil {
+			log.Printf("[cache] Warning: caching %s output failed: %v — continuing uncached", name, err)
+		}
+	}
+
+	state.Stages = append(state.Stages, types.StageRecord{
+		Name:       name,
+		InputHash:  hash,
+		OutputPath: outputPath,
+		DurationMs: time.Since(start).Milliseconds(),
+		Cached:     cached,
+	})
+	return nil
+}
+
+// loadJSON reads and unmarshals the JSON file at path into v, the
+// counterpart to saveJSON used to read back a stage's output after
+// runCachedStage (whether it was just computed or restored from cache).
+func loadJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}
+
 func saveJSON(path string, v interface{}) {
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
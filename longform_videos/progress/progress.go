@@ -0,0 +1,231 @@
+// Package progress reports structured percent/ETA progress for the
+// pipeline's long-running ffmpeg and Whisper subprocesses, which previously
+// just piped stderr straight to the terminal and gave no signal a scheduled
+// cron run could use to detect a silent hang.
+package progress
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event is one progress tick for a pipeline stage.
+type Event struct {
+	Stage      string  `json:"stage"`
+	Scene      int     `json:"scene"`
+	Percent    float64 `json:"percent"`
+	ElapsedSec float64 `json:"elapsed_sec"`
+	ETASec     float64 `json:"eta_sec"`
+	Msg        string  `json:"msg,omitempty"`
+}
+
+// Reporter receives progress Events from a running subprocess.
+type Reporter interface {
+	Report(Event)
+}
+
+// NopReporter discards every event. It's the default when no Reporter is wired in.
+type NopReporter struct{}
+
+func (NopReporter) Report(Event) {}
+
+// CLIReporter prints a single overwritten progress line to stderr.
+type CLIReporter struct {
+	mu sync.Mutex
+}
+
+// NewCLIReporter creates a Reporter that renders a live progress bar on stderr.
+func NewCLIReporter() *CLIReporter {
+	return &CLIReporter{}
+}
+
+func (c *CLIReporter) Report(e Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(os.Stderr, "\r[progress] %-12s scene=%-3d %5.1f%%  elapsed=%5.1fs  eta=%5.1fs  %s\033[K",
+		e.Stage, e.Scene, e.Percent, e.ElapsedSec, e.ETASec, e.Msg)
+	if e.Percent >= 100 {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// JSONLReporter writes one JSON-encoded Event per line — suitable for
+// scheduled/cron runs piping progress into a log aggregator.
+type JSONLReporter struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewJSONLReporter creates a Reporter that writes newline-delimited JSON to w.
+func NewJSONLReporter(w io.Writer) *JSONLReporter {
+	return &JSONLReporter{w: w}
+}
+
+func (j *JSONLReporter) Report(e Event) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	j.w.Write(append(data, '\n'))
+}
+
+func orNop(r Reporter) Reporter {
+	if r == nil {
+		return NopReporter{}
+	}
+	return r
+}
+
+// RunFFmpeg runs ffmpeg with the given args (stripped of any trailing output
+// path requirements — pass the full arg list as you would to exec.Command),
+// appending "-progress pipe:2 -nostats" and parsing the key=value progress
+// lines into Events. targetDurationSec is the known length of the output
+// (e.g. scene duration, script.TotalSec) used to turn out_time_us into a
+// percent complete.
+func RunFFmpeg(ctx context.Context, stage string, scene int, targetDurationSec float64, reporter Reporter, args ...string) error {
+	reporter = orNop(reporter)
+
+	fullArgs := append(append([]string{}, args...), "-progress", "pipe:2", "-nostats")
+	cmd := exec.CommandContext(ctx, "ffmpeg", fullArgs...)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = os.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	kv := make(map[string]string)
+	scanner := bufio.NewScanner(stderr)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		kv[key] = val
+
+		if key == "progress" {
+			reportFFmpegTick(reporter, stage, scene, kv, targetDurationSec, start)
+			kv = make(map[string]string)
+			if val == "end" {
+				break
+			}
+		}
+	}
+
+	return cmd.Wait()
+}
+
+func reportFFmpegTick(reporter Reporter, stage string, scene int, kv map[string]string, targetDurationSec float64, start time.Time) {
+	elapsed := time.Since(start).Seconds()
+
+	var percent float64
+	if outUs, err := strconv.ParseFloat(kv["out_time_us"], 64); err == nil && targetDurationSec > 0 {
+		percent = math.Min(100, (outUs/1e6)/targetDurationSec*100)
+	}
+
+	speed := parseSpeed(kv["speed"])
+	var eta float64
+	if speed > 0 && targetDurationSec > 0 {
+		remaining := targetDurationSec * (100 - percent) / 100
+		eta = remaining / speed
+	}
+
+	reporter.Report(Event{
+		Stage:      stage,
+		Scene:      scene,
+		Percent:    percent,
+		ElapsedSec: elapsed,
+		ETASec:     eta,
+		Msg:        fmt.Sprintf("frame=%s speed=%s", kv["frame"], kv["speed"]),
+	})
+}
+
+// parseSpeed parses ffmpeg's "1.23x" speed= value into a plain float.
+func parseSpeed(s string) float64 {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "x")
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// whisperTimestampRe matches Whisper's "[HH:MM:SS.mmm --> HH:MM:SS.mmm]" lines.
+var whisperTimestampRe = regexp.MustCompile(`\[(\d{2}):(\d{2}):(\d{2})\.(\d{3}) --> (\d{2}):(\d{2}):(\d{2})\.(\d{3})\]`)
+
+// RunWhisper runs the whisper CLI, tailing stderr for its segment timestamp
+// lines to derive percent complete from the latest segment end time over
+// audioDurationSec (from ffprobe).
+func RunWhisper(ctx context.Context, audioDurationSec float64, reporter Reporter, args ...string) error {
+	reporter = orNop(reporter)
+
+	cmd := exec.CommandContext(ctx, "whisper", args...)
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = os.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	scanner := bufio.NewScanner(stderr)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fmt.Fprintln(os.Stderr, line)
+
+		m := whisperTimestampRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		endSec := hmsToSeconds(m[5], m[6], m[7], m[8])
+
+		var percent float64
+		if audioDurationSec > 0 {
+			percent = math.Min(100, endSec/audioDurationSec*100)
+		}
+		elapsed := time.Since(start).Seconds()
+		var eta float64
+		if percent > 0 {
+			eta = elapsed/percent*100 - elapsed
+		}
+
+		reporter.Report(Event{
+			Stage:      "whisper",
+			Percent:    percent,
+			ElapsedSec: elapsed,
+			ETASec:     eta,
+			Msg:        line,
+		})
+	}
+
+	return cmd.Wait()
+}
+
+func hmsToSeconds(h, m, s, ms string) float64 {
+	hh, _ := strconv.Atoi(h)
+	mm, _ := strconv.Atoi(m)
+	ss, _ := strconv.Atoi(s)
+	msec, _ := strconv.Atoi(ms)
+	return float64(hh*3600+mm*60+ss) + float64(msec)/1000
+}
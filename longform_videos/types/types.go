@@ -0,0 +1,154 @@
+package types
+
+import "true-crime-pipeline/nlp"
+
+// Story holds a researched story ready for scripting
+type Story struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Body        string   `json:"body"`
+	Source      string   `json:"source"`
+	SourceURL   string   `json:"source_url"`
+	Score       int      `json:"score"`
+	PublishedAt string   `json:"published_at"`
+	ImageURLs   []string `json:"image_urls"`
+	Keywords    []string `json:"keywords"`
+}
+
+// ScriptScene is one scene/line in the script
+type ScriptScene struct {
+	Index            int      `json:"index"`
+	TimestampStart   float64  `json:"timestamp_start"`
+	TimestampEnd     float64  `json:"timestamp_end"`
+	Narration        string   `json:"narration"`
+	SceneType        string   `json:"scene_type"` // cinematic | dramatic | proof
+	Mood             string   `json:"mood"`       // tense | reveal | eerie | action | sad | hook
+	ImagePrompt      string   `json:"image_prompt"`
+	AssetTags        []string `json:"asset_tags"`
+	ProofImageURL    string   `json:"proof_image_url"`
+	ProofImageLocal  string   `json:"proof_image_local"`
+	ProofDurationSec float64  `json:"proof_duration_sec"`
+	AudioFile        string   `json:"audio_file"`
+	AudioDurationSec float64  `json:"audio_duration_sec"`
+	VisualFile       string   `json:"visual_file"`
+	SFXFile          string   `json:"sfx_file"`
+	// MusicFile is this scene's prepared slice of the background music bed
+	// — see sfx.Matcher.BuildMusicBed.
+	MusicFile string `json:"music_file"`
+	// ProofBlurhash is the blurhash string assetcache computed for this
+	// scene's downloaded proof image (empty if none was fetched, or if the
+	// image couldn't be decoded) — see visuals.ProofScraper.FetchProofImage
+	// and visuals.Assembler's degraded-card fallback.
+	ProofBlurhash string `json:"proof_blurhash,omitempty"`
+	// Entities caches nlp.ExtractEntities(Narration) so a FetchProofImage
+	// retry (e.g. after a transient ProofSource failure) doesn't reparse
+	// the same narration. Nil until FetchProofImage's first call for this
+	// scene.
+	Entities *nlp.Query `json:"entities,omitempty"`
+	// Degraded is true when this scene's visual and/or audio couldn't pass
+	// media/validate even after retrying the generator, and shipped with a
+	// fallback (a solid-color card with the narration burned in, or the
+	// last failed TTS attempt) instead of a real asset.
+	Degraded bool `json:"degraded,omitempty"`
+	// WordTimings holds per-word timestamps (relative to AudioFile's own
+	// start, i.e. 0 at the scene's first word) when the TTS provider that
+	// generated AudioFile returned alignment data. Nil when the provider
+	// doesn't support it, in which case subtitles fall back to Whisper.
+	WordTimings []WordTiming `json:"word_timings,omitempty"`
+}
+
+// WordTiming is one word's start/end time in seconds, as reported either by
+// a TTS provider's own alignment data or by Whisper's word timestamps.
+type WordTiming struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Script is the full structured script for one video
+type Script struct {
+	StoryID  string        `json:"story_id"`
+	Title    string        `json:"title"`
+	TotalSec float64       `json:"total_sec"`
+	Scenes   []ScriptScene `json:"scenes"`
+}
+
+// VideoMetadata holds all YouTube upload metadata
+type VideoMetadata struct {
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Tags             []string `json:"tags"`
+	ThumbnailPrompt  string   `json:"thumbnail_prompt"`
+	CategoryID       string   `json:"category_id"`
+	Visibility       string   `json:"visibility"`
+	ScheduledTimeUTC string   `json:"scheduled_time_utc"`
+	// Language is the BCP-47 code this metadata was generated in, e.g.
+	// "es". Empty means the pipeline's default (English) run.
+	Language string `json:"language,omitempty"`
+	// TitleVariants holds every A/B title generated alongside Title (which
+	// is always TitleVariants[0] when this is set) — see
+	// metadata.Generator.Run and visuals.ThumbnailGenerator.Candidates,
+	// which pairs each variant with its own thumbnail hook.
+	TitleVariants []string `json:"title_variants,omitempty"`
+}
+
+// VariantState tracks one additional-language localization of a video
+// within a PipelineState: its own script, audio, subtitles, render, and
+// upload, separate from the primary run's top-level fields so a failure
+// in one language doesn't lose the others.
+type VariantState struct {
+	Language   string         `json:"language"`
+	Script     *Script        `json:"script"`
+	AudioFile  string         `json:"audio_file"`
+	SRTFile    string         `json:"srt_file,omitempty"`
+	VideoFile  string         `json:"video_file"`
+	Metadata   *VideoMetadata `json:"metadata"`
+	YouTubeURL string         `json:"youtube_url"`
+	YouTubeID  string         `json:"youtube_id"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// StageRecord is one stage's cache bookkeeping, appended to
+// PipelineState.Stages as each stage completes — see cache.Store and
+// pipeline.go's runCachedStage.
+type StageRecord struct {
+	Name string `json:"name"`
+	// InputHash is cache.Key's hash of whatever determines this stage's
+	// output (the prior stage's data plus the relevant config subset).
+	InputHash  string `json:"input_hash"`
+	OutputPath string `json:"output_path"`
+	DurationMs int64  `json:"duration_ms"`
+	// Cached is true when this stage's output was restored from
+	// cache.Store instead of recomputed.
+	Cached bool `json:"cached"`
+}
+
+// PipelineState tracks the full state of one pipeline run
+type PipelineState struct {
+	RunID       string `json:"run_id"`
+	StartedAt   string `json:"started_at"`
+	CompletedAt string `json:"completed_at"`
+	// Stages records every cache-eligible stage's input hash, output path,
+	// duration, and whether it was served from cache.Store — see
+	// pipeline.go's runCachedStage.
+	Stages      []StageRecord  `json:"stages,omitempty"`
+	Story       *Story         `json:"story"`
+	Script      *Script        `json:"script"`
+	AudioFile   string         `json:"audio_file"`
+	VideoFile   string         `json:"video_file"`
+	HLSPlaylist string         `json:"hls_playlist,omitempty"`
+	Metadata    *VideoMetadata `json:"metadata"`
+	YouTubeURL  string         `json:"youtube_url"`
+	YouTubeID   string         `json:"youtube_id"`
+	// ThumbnailCandidates holds every A/B thumbnail variant
+	// ThumbnailGenerator produced, and ThumbnailChosen the one attached via
+	// Thumbnails.Set — together the hook a future analytics pass uses to
+	// rotate in one of the others via Thumbnails.Set and correlate CTR
+	// with variant.
+	ThumbnailCandidates []string `json:"thumbnail_candidates,omitempty"`
+	ThumbnailChosen     string   `json:"thumbnail_chosen,omitempty"`
+	// Variants holds one entry per configured Localization.Languages
+	// entry — additional-language videos derived from this same run.
+	Variants []VariantState `json:"variants,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
@@ -0,0 +1,298 @@
+// Package useragent is a rotating, weighted pool of realistic browser
+// identities (User-Agent plus the handful of headers real browsers send
+// alongside it), shared by assetcache.Store and the scraped ProofSource
+// backends. A single hardcoded UA string is itself a fingerprint — several
+// news sites and image hosts that host true-crime evidence photos 403
+// requests carrying one once they notice it. The pool periodically
+// refreshes its mix from caniuse's published browser-version-share data so
+// it tracks real-world traffic instead of going stale, falling back to a
+// small embedded snapshot when that fetch fails (no network, caniuse
+// unreachable) — the same attempt-then-degrade shape as webdriver.Renderer
+// and duckDuckGoSource.
+package useragent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"true-crime-pipeline/config"
+)
+
+// Headers is one realistic browser identity Pick returns.
+type Headers struct {
+	UserAgent      string
+	AcceptLanguage string
+	SecChUa        string // empty for Firefox entries, which don't send it
+	Referer        string // may be "", meaning no Referer header at all
+}
+
+// Apply sets req's User-Agent, Accept-Language, and (when non-empty)
+// Sec-Ch-Ua and Referer from h. Headers are always applied together —
+// a Chrome UA with no Sec-Ch-Ua, or Firefox's UA with one, is itself a
+// tell that flags naive scrapers.
+func (h Headers) Apply(req *http.Request) {
+	req.Header.Set("User-Agent", h.UserAgent)
+	req.Header.Set("Accept-Language", h.AcceptLanguage)
+	if h.SecChUa != "" {
+		req.Header.Set("Sec-Ch-Ua", h.SecChUa)
+	}
+	if h.Referer != "" {
+		req.Header.Set("Referer", h.Referer)
+	}
+}
+
+// entry is one weighted browser identity in the pool.
+type entry struct {
+	ua      string
+	secChUa string
+	weight  float64
+}
+
+// Pool is a mutex-guarded, periodically-refreshed weighted pool of User-Agent
+// strings. It's safe for concurrent use.
+type Pool struct {
+	httpClient *http.Client
+	lifetime   time.Duration
+
+	mu        sync.Mutex
+	rng       *rand.Rand
+	entries   []entry
+	fetchedAt time.Time
+}
+
+// NewPool builds a Pool from cfg, seeded with staticPoolEntries until the
+// first Pick triggers a live refresh. httpClient may be nil, in which case
+// Pick uses its own client with a 10s timeout for the browser-share fetch.
+// cfg.Seed makes Pick's weighted-random choice deterministic (0 seeds from
+// the current time instead), for callers that want reproducible tests.
+func NewPool(cfg config.UserAgentConfig, httpClient *http.Client) *Pool {
+	refreshHours := cfg.RefreshHours
+	if refreshHours <= 0 {
+		refreshHours = 24
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Pool{
+		httpClient: httpClient,
+		lifetime:   time.Duration(refreshHours) * time.Hour,
+		rng:        rand.New(rand.NewSource(seed)),
+		entries:    staticPoolEntries(),
+	}
+}
+
+// Pick returns one weighted-random Headers, refreshing the pool's
+// browser-share snapshot first if it's older than the configured
+// RefreshHours (or has never been fetched). A failed refresh just keeps
+// using whatever entries are already loaded — the static default the
+// first time, or the last successful fetch after — so a stale caniuse
+// snapshot never blocks a request that would otherwise succeed.
+func (p *Pool) Pick(ctx context.Context) Headers {
+	p.mu.Lock()
+	stale := time.Since(p.fetchedAt) > p.lifetime
+	p.mu.Unlock()
+
+	// The caniuse round-trip runs without holding p.mu, so one goroutine's
+	// refresh can't stall every other concurrent Pick (ProofScraper fans
+	// queries out to several sources sharing one Pool) behind it.
+	if stale {
+		fresh, err := fetchBrowserShareEntries(ctx, p.httpClient)
+		p.mu.Lock()
+		if err == nil && len(fresh) > 0 {
+			p.entries = fresh
+		}
+		p.fetchedAt = time.Now()
+		p.mu.Unlock()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e := weightedPick(p.rng, p.entries)
+	return Headers{
+		UserAgent:      e.ua,
+		AcceptLanguage: acceptLanguages[p.rng.Intn(len(acceptLanguages))],
+		SecChUa:        e.secChUa,
+		Referer:        referers[p.rng.Intn(len(referers))],
+	}
+}
+
+// weightedPick returns a random entry, weighted by its share. Falls back
+// to a uniform pick if every weight is non-positive (malformed data).
+func weightedPick(rng *rand.Rand, entries []entry) entry {
+	var total float64
+	for _, e := range entries {
+		total += e.weight
+	}
+	if total <= 0 {
+		return entries[rng.Intn(len(entries))]
+	}
+	r := rng.Float64() * total
+	for _, e := range entries {
+		r -= e.weight
+		if r <= 0 {
+			return e
+		}
+	}
+	return entries[len(entries)-1]
+}
+
+// caniuseDataURL is caniuse's published fulldata export, whose
+// agents.<browser>.usage_global maps a version string to its global usage
+// percentage — the same browser-share signal the external agent.go this
+// request references draws its pool from.
+const caniuseDataURL = "https://raw.githubusercontent.com/Fyrd/caniuse/main/fulldata-json/data-2.0.json"
+
+// fetchBrowserShareEntries fetches and parses caniuseDataURL into a
+// weighted entry list covering Chrome and Firefox's modern (evergreen,
+// major version >= 100) releases.
+func fetchBrowserShareEntries(ctx context.Context, client *http.Client) ([]entry, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", caniuseDataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("useragent: caniuse data returned %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Agents map[string]struct {
+			UsageGlobal map[string]float64 `json:"usage_global"`
+		} `json:"agents"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 8*1024*1024)).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	if chrome, ok := payload.Agents["chrome"]; ok {
+		entries = append(entries, chromeEntries(chrome.UsageGlobal)...)
+	}
+	if firefox, ok := payload.Agents["firefox"]; ok {
+		entries = append(entries, firefoxEntries(firefox.UsageGlobal)...)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("useragent: no usable chrome/firefox entries in caniuse data")
+	}
+	return entries, nil
+}
+
+var chromePlatforms = []string{
+	"Windows NT 10.0; Win64; x64",
+	"Macintosh; Intel Mac OS X 10_15_7",
+	"X11; Linux x86_64",
+}
+
+var firefoxPlatforms = []string{
+	"Windows NT 10.0; Win64; x64; rv:%d.0",
+	"Macintosh; Intel Mac OS X 10.15; rv:%d.0",
+	"X11; Linux x86_64; rv:%d.0",
+}
+
+// chromeEntries builds one weighted entry per modern Chrome major version
+// in usage, cycling through chromePlatforms for desktop-OS variety.
+func chromeEntries(usage map[string]float64) []entry {
+	var out []entry
+	for version, share := range usage {
+		major, ok := majorVersion(version)
+		if !ok || major < 100 || share <= 0 {
+			continue
+		}
+		platform := chromePlatforms[len(out)%len(chromePlatforms)]
+		ua := fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.0.0 Safari/537.36", platform, major)
+		secChUa := fmt.Sprintf(`"Chromium";v="%d", "Google Chrome";v="%d", "Not-A.Brand";v="99"`, major, major)
+		out = append(out, entry{ua: ua, secChUa: secChUa, weight: share})
+	}
+	return out
+}
+
+// firefoxEntries builds one weighted entry per modern Firefox major
+// version in usage. Firefox entries carry no secChUa — real Firefox
+// doesn't implement the Client Hints header.
+func firefoxEntries(usage map[string]float64) []entry {
+	var out []entry
+	for version, share := range usage {
+		major, ok := majorVersion(version)
+		if !ok || major < 100 || share <= 0 {
+			continue
+		}
+		platform := fmt.Sprintf(firefoxPlatforms[len(out)%len(firefoxPlatforms)], major)
+		ua := fmt.Sprintf("Mozilla/5.0 (%s) Gecko/20100101 Firefox/%d.0", platform, major)
+		out = append(out, entry{ua: ua, weight: share})
+	}
+	return out
+}
+
+// majorVersion parses version's leading integer component ("124.0.1" ->
+// 124), returning ok=false for anything non-numeric (caniuse also keys
+// some browsers by range strings like "4-7").
+func majorVersion(version string) (int, bool) {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
+
+// staticPoolEntries is the embedded fallback pool used until the first
+// live refresh succeeds, and forever after in a sandbox with no network
+// access — a rough snapshot of desktop Chrome/Firefox's global share.
+func staticPoolEntries() []entry {
+	return []entry{
+		{
+			ua:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			secChUa: `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+			weight:  28.5,
+		},
+		{
+			ua:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			secChUa: `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+			weight:  14.2,
+		},
+		{
+			ua:      "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/123.0.0.0 Safari/537.36",
+			secChUa: `"Chromium";v="123", "Google Chrome";v="123", "Not-A.Brand";v="99"`,
+			weight:  6.1,
+		},
+		{
+			ua:     "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			weight: 3.4,
+		},
+		{
+			ua:     "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:124.0) Gecko/20100101 Firefox/124.0",
+			weight: 2.2,
+		},
+	}
+}
+
+var acceptLanguages = []string{
+	"en-US,en;q=0.9",
+	"en-US,en;q=0.9,es;q=0.8",
+	"en-GB,en;q=0.9,en-US;q=0.8",
+}
+
+// referers mirrors the small set of plausible "how a visitor arrived"
+// values a real browser's Referer might carry; "" means no Referer header
+// at all (also realistic — plenty of direct navigations carry none).
+var referers = []string{
+	"",
+	"https://www.google.com/",
+	"https://www.bing.com/",
+	"https://duckduckgo.com/",
+}
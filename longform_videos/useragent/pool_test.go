@@ -0,0 +1,85 @@
+package useragent
+
+import (
+	"math/rand"
+	"net/http"
+	"testing"
+)
+
+func TestMajorVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		want    int
+		wantOK  bool
+	}{
+		{"124.0.1", 124, true},
+		{"99", 99, true},
+		{"4-7", 0, false},
+		{"", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := majorVersion(c.version)
+		if ok != c.wantOK || (ok && got != c.want) {
+			t.Errorf("majorVersion(%q) = (%d, %v), want (%d, %v)", c.version, got, ok, c.want, c.wantOK)
+		}
+	}
+}
+
+func TestWeightedPickAlwaysPicksSoleNonZeroWeightEntry(t *testing.T) {
+	entries := []entry{
+		{ua: "zero-a", weight: 0},
+		{ua: "heavy", weight: 100},
+		{ua: "zero-b", weight: 0},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		got := weightedPick(rng, entries)
+		if got.ua != "heavy" {
+			t.Fatalf("weightedPick picked %q, want the only nonzero-weight entry %q", got.ua, "heavy")
+		}
+	}
+}
+
+func TestWeightedPickFallsBackToUniformWhenAllWeightsNonPositive(t *testing.T) {
+	entries := []entry{{ua: "a", weight: 0}, {ua: "b", weight: 0}}
+	rng := rand.New(rand.NewSource(1))
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		seen[weightedPick(rng, entries).ua] = true
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected both zero-weight entries to be reachable via the uniform fallback, saw %v", seen)
+	}
+}
+
+func TestHeadersApplyOmitsEmptyOptionalHeaders(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	h := Headers{UserAgent: "UA/1.0", AcceptLanguage: "en-US"}
+	h.Apply(req)
+
+	if got := req.Header.Get("User-Agent"); got != "UA/1.0" {
+		t.Errorf("User-Agent = %q", got)
+	}
+	if got := req.Header.Get("Accept-Language"); got != "en-US" {
+		t.Errorf("Accept-Language = %q", got)
+	}
+	if req.Header.Get("Sec-Ch-Ua") != "" {
+		t.Error("Sec-Ch-Ua should be unset when Headers.SecChUa is empty")
+	}
+	if req.Header.Get("Referer") != "" {
+		t.Error("Referer should be unset when Headers.Referer is empty")
+	}
+}
+
+func TestHeadersApplySetsOptionalHeadersWhenPresent(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	h := Headers{UserAgent: "UA/1.0", SecChUa: `"Chrome";v="124"`, Referer: "https://google.com"}
+	h.Apply(req)
+
+	if got := req.Header.Get("Sec-Ch-Ua"); got != `"Chrome";v="124"` {
+		t.Errorf("Sec-Ch-Ua = %q", got)
+	}
+	if got := req.Header.Get("Referer"); got != "https://google.com" {
+		t.Errorf("Referer = %q", got)
+	}
+}